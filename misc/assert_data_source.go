@@ -0,0 +1,147 @@
+package misc
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &AssertDataSource{}
+
+func NewAssertDataSource() datasource.DataSource {
+	return &AssertDataSource{}
+}
+
+// AssertDataSource defines the data source implementation.
+type AssertDataSource struct{}
+
+// AssertDataSourceModel describes the data source data model.
+type AssertDataSourceModel struct {
+	Id     types.String `tfsdk:"id"`
+	Checks types.List   `tfsdk:"checks"`
+	Vars   types.Map    `tfsdk:"vars"`
+	Failed types.List   `tfsdk:"failed"`
+	Passed types.Bool   `tfsdk:"passed"`
+}
+
+// AssertCheckModel describes a single check of the assert data source.
+type AssertCheckModel struct {
+	Condition types.Bool   `tfsdk:"condition"`
+	Severity  types.String `tfsdk:"severity"`
+	Summary   types.String `tfsdk:"summary"`
+	Details   types.String `tfsdk:"details"`
+}
+
+func (d *AssertDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_assert"
+}
+
+func (d *AssertDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Evaluates a list of checks and reports all failing ones at once",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Assert identifier",
+				Computed:            true,
+			},
+			"checks": schema.ListNestedAttribute{
+				MarkdownDescription: "Checks to evaluate",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"condition": schema.BoolAttribute{
+							MarkdownDescription: "Check condition, the check fails when it is false",
+							Required:            true,
+						},
+						"severity": schema.StringAttribute{
+							MarkdownDescription: "Severity of the failed check, `error` (default), `warning` or `info` (only logged)",
+							Optional:            true,
+						},
+						"summary": schema.StringAttribute{
+							MarkdownDescription: "Check message summary",
+							Required:            true,
+						},
+						"details": schema.StringAttribute{
+							MarkdownDescription: "Check message details",
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"vars": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Variables available in `summary` and `details` of every check, which are rendered as Go templates (e.g. `{{ .name }}`) only when `vars` is set",
+				Optional:            true,
+			},
+			"failed": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Summaries of the failed checks",
+				Computed:            true,
+			},
+			"passed": schema.BoolAttribute{
+				MarkdownDescription: "Whether all the checks passed",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *AssertDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AssertDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	checks := []AssertCheckModel{}
+	resp.Diagnostics.Append(data.Checks.ElementsAs(ctx, &checks, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	failed := []string{}
+	for i, c := range checks {
+		var checkDiags diag.Diagnostics
+		validateSeverity(path.Root("checks").AtListIndex(i).AtName("severity"), c.Severity, &checkDiags)
+		resp.Diagnostics.Append(checkDiags...)
+		if checkDiags.HasError() {
+			continue
+		}
+
+		severity := severityError
+		if !c.Severity.IsNull() {
+			severity = c.Severity.ValueString()
+		}
+
+		if c.Condition.ValueBool() {
+			continue
+		}
+
+		summary, details := renderMessage(ctx, path.Root("checks").AtListIndex(i), c.Summary, c.Details, data.Vars, &checkDiags)
+		resp.Diagnostics.Append(checkDiags...)
+		if checkDiags.HasError() {
+			continue
+		}
+		failed = append(failed, summary)
+
+		addCheckDiagnostic(ctx, &resp.Diagnostics, path.Empty(), severity, summary, details)
+	}
+
+	failedList, diags := types.ListValueFrom(ctx, types.StringType, failed)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(time.Now().Format(time.RFC3339Nano))
+	data.Failed = failedList
+	data.Passed = types.BoolValue(len(failed) == 0)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,213 @@
+package misc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// maxBatchCount limits the number of batches, which are allocated upfront.
+const maxBatchCount = 10000
+
+var _ datasource.DataSource = &BatchesDataSource{}
+
+func NewBatchesDataSource() datasource.DataSource {
+	return &BatchesDataSource{}
+}
+
+// BatchesDataSource defines the data source implementation.
+type BatchesDataSource struct{}
+
+// BatchesDataSourceModel describes the data source data model.
+type BatchesDataSourceModel struct {
+	Id             types.String `tfsdk:"id"`
+	Input          types.List   `tfsdk:"input"`
+	Size           types.Int64  `tfsdk:"size"`
+	Count          types.Int64  `tfsdk:"count"`
+	MaxWeight      types.Int64  `tfsdk:"max_weight"`
+	Weights        types.Map    `tfsdk:"weights"`
+	Result         types.List   `tfsdk:"result"`
+	ElementBatches types.Map    `tfsdk:"element_batches"`
+}
+
+func (d *BatchesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_batches"
+}
+
+func (d *BatchesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Splits a list into batches by `size`, by `count` or by `max_weight`. " +
+			"Only `count` keeps the elements in their batches when other elements are inserted or removed, " +
+			"`size` and `max_weight` fill the batches in the list order like `chunklist`, so only appending elements is stable",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Batches identifier",
+				Computed:            true,
+			},
+			"input": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of unique elements to split",
+				Required:            true,
+			},
+			"size": schema.Int64Attribute{
+				MarkdownDescription: "Number of elements per batch, batches are filled in the list order, so inserting or removing an element moves the later ones",
+				Optional:            true,
+			},
+			"count": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Number of batches, at most %d. Elements are placed by jump consistent hashing, "+
+					"so inserting an element doesn't move the others and changing the count moves as few elements as possible", maxBatchCount),
+				Optional: true,
+			},
+			"max_weight": schema.Int64Attribute{
+				MarkdownDescription: "Maximum total weight of a batch, batches are filled in the list order, so inserting or removing an element moves the later ones",
+				Optional:            true,
+			},
+			"weights": schema.MapAttribute{
+				ElementType:         types.Int64Type,
+				MarkdownDescription: "Weights of the elements used with `max_weight` (element => weight), elements not listed have weight 1",
+				Optional:            true,
+			},
+			"result": schema.ListAttribute{
+				ElementType:         types.ListType{ElemType: types.StringType},
+				MarkdownDescription: "Batches of elements, elements keep the list order",
+				Computed:            true,
+			},
+			"element_batches": schema.MapAttribute{
+				ElementType:         types.Int64Type,
+				MarkdownDescription: "Map of elements and indexes of their batches (element => batch index)",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *BatchesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data BatchesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input := []string{}
+	weights := map[string]int64{}
+	resp.Diagnostics.Append(data.Input.ElementsAs(ctx, &input, false)...)
+	if !data.Weights.IsNull() {
+		resp.Diagnostics.Append(data.Weights.ElementsAs(ctx, &weights, false)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	modes := 0
+	for _, v := range []types.Int64{data.Size, data.Count, data.MaxWeight} {
+		if !v.IsNull() {
+			modes++
+		}
+	}
+	if modes != 1 {
+		resp.Diagnostics.AddError("Invalid batching", "Exactly one of size, count or max_weight must be set.")
+		return
+	}
+	if !data.Weights.IsNull() && data.MaxWeight.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("weights"), "Invalid batching", "Weights can only be used with max_weight.")
+		return
+	}
+
+	elementBatches := map[string]int64{}
+	for i, e := range input {
+		if _, ok := elementBatches[e]; ok {
+			resp.Diagnostics.AddAttributeError(path.Root("input").AtListIndex(i), "Duplicate element",
+				fmt.Sprintf("Element %q is listed more than once.", e))
+		}
+		elementBatches[e] = 0
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result := [][]string{}
+	switch {
+	case !data.Size.IsNull():
+		size := data.Size.ValueInt64()
+		if size < 1 {
+			resp.Diagnostics.AddAttributeError(path.Root("size"), "Invalid batch size",
+				fmt.Sprintf("Batch size must be positive, got %d.", size))
+			return
+		}
+		for i, e := range input {
+			if int64(i)%size == 0 {
+				result = append(result, []string{})
+			}
+			result[len(result)-1] = append(result[len(result)-1], e)
+		}
+	case !data.Count.IsNull():
+		count := data.Count.ValueInt64()
+		if count < 1 || count > maxBatchCount {
+			resp.Diagnostics.AddAttributeError(path.Root("count"), "Invalid batch count",
+				fmt.Sprintf("Batch count must be between 1 and %d, got %d.", maxBatchCount, count))
+			return
+		}
+		result = make([][]string, count)
+		for i := range result {
+			result[i] = []string{}
+		}
+		for _, e := range input {
+			b := jumpHash(hash64(e), count)
+			result[b] = append(result[b], e)
+		}
+	default:
+		maxWeight := data.MaxWeight.ValueInt64()
+		if maxWeight < 1 {
+			resp.Diagnostics.AddAttributeError(path.Root("max_weight"), "Invalid maximum weight",
+				fmt.Sprintf("Maximum weight must be positive, got %d.", maxWeight))
+			return
+		}
+		total := int64(0)
+		for _, e := range input {
+			weight, ok := weights[e]
+			if !ok {
+				weight = 1
+			}
+			if weight < 0 || weight > maxWeight {
+				resp.Diagnostics.AddAttributeError(path.Root("weights").AtMapKey(e), "Invalid weight",
+					fmt.Sprintf("Weight of %q must be between 0 and max_weight %d, got %d.", e, maxWeight, weight))
+				continue
+			}
+			if len(result) == 0 || total+weight > maxWeight {
+				result = append(result, []string{})
+				total = 0
+			}
+			result[len(result)-1] = append(result[len(result)-1], e)
+			total += weight
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	for i, batch := range result {
+		for _, e := range batch {
+			elementBatches[e] = int64(i)
+		}
+	}
+
+	rv, diags := types.ListValueFrom(ctx, types.ListType{ElemType: types.StringType}, result)
+	resp.Diagnostics.Append(diags...)
+	ev, diags := types.MapValueFrom(ctx, types.Int64Type, elementBatches)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(time.Now().Format(time.RFC3339Nano))
+	data.Result = rv
+	data.ElementBatches = ev
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
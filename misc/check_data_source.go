@@ -0,0 +1,101 @@
+package misc
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &CheckDataSource{}
+
+func NewCheckDataSource() datasource.DataSource {
+	return &CheckDataSource{}
+}
+
+// CheckDataSource defines the data source implementation.
+type CheckDataSource struct{}
+
+// CheckDataSourceModel describes the data source data model.
+type CheckDataSourceModel struct {
+	Id              types.String `tfsdk:"id"`
+	Condition       types.Bool   `tfsdk:"condition"`
+	Summary         types.String `tfsdk:"summary"`
+	Details         types.String `tfsdk:"details"`
+	Vars            types.Map    `tfsdk:"vars"`
+	Passed          types.Bool   `tfsdk:"passed"`
+	RenderedSummary types.String `tfsdk:"rendered_summary"`
+	RenderedDetails types.String `tfsdk:"rendered_details"`
+}
+
+func (d *CheckDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_check"
+}
+
+func (d *CheckDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Evaluates a condition and exposes the result as data instead of emitting a diagnostic",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Check identifier",
+				Computed:            true,
+			},
+			"condition": schema.BoolAttribute{
+				MarkdownDescription: "Check condition, the check fails when it is false",
+				Required:            true,
+			},
+			"summary": schema.StringAttribute{
+				MarkdownDescription: "Check message summary",
+				Required:            true,
+			},
+			"details": schema.StringAttribute{
+				MarkdownDescription: "Check message details",
+				Optional:            true,
+			},
+			"vars": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Variables available in `summary` and `details`, which are rendered as Go templates (e.g. `{{ .name }}`) only when `vars` is set",
+				Optional:            true,
+			},
+			"passed": schema.BoolAttribute{
+				MarkdownDescription: "Whether the check passed",
+				Computed:            true,
+			},
+			"rendered_summary": schema.StringAttribute{
+				MarkdownDescription: "Check message summary with `vars` rendered",
+				Computed:            true,
+			},
+			"rendered_details": schema.StringAttribute{
+				MarkdownDescription: "Check message details with `vars` rendered, null when `details` is not set",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *CheckDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CheckDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	summary, details := renderMessage(ctx, path.Empty(), data.Summary, data.Details, data.Vars, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(time.Now().Format(time.RFC3339Nano))
+	data.Passed = types.BoolValue(data.Condition.ValueBool())
+	data.RenderedSummary = types.StringValue(summary)
+	data.RenderedDetails = types.StringNull()
+	if !data.Details.IsNull() {
+		data.RenderedDetails = types.StringValue(details)
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
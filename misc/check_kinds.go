@@ -0,0 +1,327 @@
+package misc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// checkKindsModel holds the typed checks shared by the error and warning data sources.
+type checkKindsModel struct {
+	RegexMatch          types.Object
+	CidrsNonOverlapping types.List
+	SemverSatisfies     types.Object
+	UniqueValues        types.List
+	SubsetOf            types.Object
+	WithinRange         types.Object
+}
+
+type regexMatchModel struct {
+	Pattern types.String `tfsdk:"pattern"`
+	Values  types.List   `tfsdk:"values"`
+}
+
+type semverSatisfiesModel struct {
+	Constraint types.String `tfsdk:"constraint"`
+	Versions   types.List   `tfsdk:"versions"`
+}
+
+type subsetOfModel struct {
+	Values  types.List `tfsdk:"values"`
+	Allowed types.List `tfsdk:"allowed"`
+}
+
+type withinRangeModel struct {
+	Min    types.Float64 `tfsdk:"min"`
+	Max    types.Float64 `tfsdk:"max"`
+	Values types.List    `tfsdk:"values"`
+}
+
+// checkKindsAttributes returns the schema attributes of the typed checks.
+func checkKindsAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"regex_match": schema.SingleNestedAttribute{
+			MarkdownDescription: "Fails when any of `values` doesn't match the regular expression `pattern`",
+			Optional:            true,
+			Attributes: map[string]schema.Attribute{
+				"pattern": schema.StringAttribute{
+					MarkdownDescription: "Regular expression in the Go RE2 syntax",
+					Required:            true,
+				},
+				"values": schema.ListAttribute{
+					ElementType:         types.StringType,
+					MarkdownDescription: "Values to match",
+					Required:            true,
+				},
+			},
+		},
+		"cidrs_non_overlapping": schema.ListAttribute{
+			ElementType:         types.StringType,
+			MarkdownDescription: "Fails when any two of the CIDRs overlap",
+			Optional:            true,
+		},
+		"semver_satisfies": schema.SingleNestedAttribute{
+			MarkdownDescription: "Fails when any of `versions` doesn't satisfy the `constraint`",
+			Optional:            true,
+			Attributes: map[string]schema.Attribute{
+				"constraint": schema.StringAttribute{
+					MarkdownDescription: "Version constraint, e.g. `>= 1.2, < 2.0` or `~> 1.2`",
+					Required:            true,
+				},
+				"versions": schema.ListAttribute{
+					ElementType:         types.StringType,
+					MarkdownDescription: "Versions to check",
+					Required:            true,
+				},
+			},
+		},
+		"unique_values": schema.ListAttribute{
+			ElementType:         types.StringType,
+			MarkdownDescription: "Fails when any of the values is duplicated",
+			Optional:            true,
+		},
+		"subset_of": schema.SingleNestedAttribute{
+			MarkdownDescription: "Fails when any of `values` is not in `allowed`",
+			Optional:            true,
+			Attributes: map[string]schema.Attribute{
+				"values": schema.ListAttribute{
+					ElementType:         types.StringType,
+					MarkdownDescription: "Values to check",
+					Required:            true,
+				},
+				"allowed": schema.ListAttribute{
+					ElementType:         types.StringType,
+					MarkdownDescription: "Allowed values",
+					Required:            true,
+				},
+			},
+		},
+		"within_range": schema.SingleNestedAttribute{
+			MarkdownDescription: "Fails when any of `values` is lower than `min` or higher than `max`",
+			Optional:            true,
+			Attributes: map[string]schema.Attribute{
+				"min": schema.Float64Attribute{
+					MarkdownDescription: "Inclusive lower bound",
+					Optional:            true,
+				},
+				"max": schema.Float64Attribute{
+					MarkdownDescription: "Inclusive upper bound",
+					Optional:            true,
+				},
+				"values": schema.ListAttribute{
+					ElementType:         types.Float64Type,
+					MarkdownDescription: "Values to check",
+					Required:            true,
+				},
+			},
+		},
+	}
+}
+
+// isSet reports whether any of the typed checks is configured.
+func (m checkKindsModel) isSet() bool {
+	return !m.RegexMatch.IsNull() ||
+		!m.CidrsNonOverlapping.IsNull() ||
+		!m.SemverSatisfies.IsNull() ||
+		!m.UniqueValues.IsNull() ||
+		!m.SubsetOf.IsNull() ||
+		!m.WithinRange.IsNull()
+}
+
+// evaluate runs the configured typed checks and returns a description of every failed one.
+func (m checkKindsModel) evaluate(ctx context.Context, diags *diag.Diagnostics) (failures []string) {
+	if !m.RegexMatch.IsNull() {
+		var rm regexMatchModel
+		diags.Append(m.RegexMatch.As(ctx, &rm, basetypes.ObjectAsOptions{})...)
+		values := []string{}
+		diags.Append(rm.Values.ElementsAs(ctx, &values, false)...)
+		if diags.HasError() {
+			return
+		}
+
+		re, err := regexp.Compile(rm.Pattern.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("regex_match").AtName("pattern"), "Invalid regular expression", err.Error())
+			return
+		}
+
+		offending := []string{}
+		for _, v := range values {
+			if !re.MatchString(v) {
+				offending = append(offending, fmt.Sprintf("%q", v))
+			}
+		}
+		if len(offending) > 0 {
+			failures = append(failures, fmt.Sprintf("Values not matching %q: %s.", re.String(), strings.Join(offending, ", ")))
+		}
+	}
+
+	if !m.CidrsNonOverlapping.IsNull() {
+		cidrs := []string{}
+		diags.Append(m.CidrsNonOverlapping.ElementsAs(ctx, &cidrs, false)...)
+		if diags.HasError() {
+			return
+		}
+
+		nets := make([]*net.IPNet, len(cidrs))
+		invalid := []string{}
+		for i, c := range cidrs {
+			_, n, err := net.ParseCIDR(c)
+			if err != nil {
+				invalid = append(invalid, fmt.Sprintf("%q", c))
+				continue
+			}
+			nets[i] = n
+		}
+		if len(invalid) > 0 {
+			failures = append(failures, fmt.Sprintf("Invalid CIDRs: %s.", strings.Join(invalid, ", ")))
+		}
+
+		overlapping := []string{}
+		for i := range nets {
+			for j := i + 1; j < len(nets); j++ {
+				if nets[i] == nil || nets[j] == nil {
+					continue
+				}
+				if nets[i].Contains(nets[j].IP) || nets[j].Contains(nets[i].IP) {
+					overlapping = append(overlapping, fmt.Sprintf("%s and %s", cidrs[i], cidrs[j]))
+				}
+			}
+		}
+		if len(overlapping) > 0 {
+			failures = append(failures, fmt.Sprintf("Overlapping CIDRs: %s.", strings.Join(overlapping, "; ")))
+		}
+	}
+
+	if !m.SemverSatisfies.IsNull() {
+		var ss semverSatisfiesModel
+		diags.Append(m.SemverSatisfies.As(ctx, &ss, basetypes.ObjectAsOptions{})...)
+		versions := []string{}
+		diags.Append(ss.Versions.ElementsAs(ctx, &versions, false)...)
+		if diags.HasError() {
+			return
+		}
+
+		constraints, err := version.NewConstraint(ss.Constraint.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("semver_satisfies").AtName("constraint"), "Invalid version constraint", err.Error())
+			return
+		}
+
+		invalid := []string{}
+		offending := []string{}
+		for _, s := range versions {
+			v, err := version.NewVersion(s)
+			if err != nil {
+				invalid = append(invalid, fmt.Sprintf("%q", s))
+				continue
+			}
+			if !constraints.Check(v) {
+				offending = append(offending, s)
+			}
+		}
+		if len(invalid) > 0 {
+			failures = append(failures, fmt.Sprintf("Invalid versions: %s.", strings.Join(invalid, ", ")))
+		}
+		if len(offending) > 0 {
+			failures = append(failures, fmt.Sprintf("Versions not satisfying %q: %s.", constraints.String(), strings.Join(offending, ", ")))
+		}
+	}
+
+	if !m.UniqueValues.IsNull() {
+		values := []string{}
+		diags.Append(m.UniqueValues.ElementsAs(ctx, &values, false)...)
+		if diags.HasError() {
+			return
+		}
+
+		counts := map[string]int{}
+		for _, v := range values {
+			counts[v]++
+		}
+		duplicated := []string{}
+		for v, c := range counts {
+			if c > 1 {
+				duplicated = append(duplicated, fmt.Sprintf("%q (%d times)", v, c))
+			}
+		}
+		sort.Strings(duplicated)
+		if len(duplicated) > 0 {
+			failures = append(failures, fmt.Sprintf("Duplicated values: %s.", strings.Join(duplicated, ", ")))
+		}
+	}
+
+	if !m.SubsetOf.IsNull() {
+		var so subsetOfModel
+		diags.Append(m.SubsetOf.As(ctx, &so, basetypes.ObjectAsOptions{})...)
+		values := []string{}
+		allowed := []string{}
+		diags.Append(so.Values.ElementsAs(ctx, &values, false)...)
+		diags.Append(so.Allowed.ElementsAs(ctx, &allowed, false)...)
+		if diags.HasError() {
+			return
+		}
+
+		offending := []string{}
+		for _, v := range values {
+			if !stringInSlice(v, allowed) {
+				offending = append(offending, fmt.Sprintf("%q", v))
+			}
+		}
+		if len(offending) > 0 {
+			failures = append(failures, fmt.Sprintf("Values not allowed: %s.", strings.Join(offending, ", ")))
+		}
+	}
+
+	if !m.WithinRange.IsNull() {
+		var wr withinRangeModel
+		diags.Append(m.WithinRange.As(ctx, &wr, basetypes.ObjectAsOptions{})...)
+		values := []float64{}
+		diags.Append(wr.Values.ElementsAs(ctx, &values, false)...)
+		if diags.HasError() {
+			return
+		}
+
+		offending := []string{}
+		for _, v := range values {
+			if (!wr.Min.IsNull() && v < wr.Min.ValueFloat64()) || (!wr.Max.IsNull() && v > wr.Max.ValueFloat64()) {
+				offending = append(offending, fmt.Sprint(v))
+			}
+		}
+		if len(offending) > 0 {
+			failures = append(failures, fmt.Sprintf("Values out of range %s: %s.",
+				formatRange(wr.Min, wr.Max), strings.Join(offending, ", ")))
+		}
+	}
+
+	return
+}
+
+func formatRange(min, max types.Float64) string {
+	lower, upper := "-inf", "+inf"
+	if !min.IsNull() {
+		lower = fmt.Sprint(min.ValueFloat64())
+	}
+	if !max.IsNull() {
+		upper = fmt.Sprint(max.ValueFloat64())
+	}
+	return fmt.Sprintf("[%s, %s]", lower, upper)
+}
+
+// validateCondition ensures there is something to check when condition is optional.
+func (m checkKindsModel) validateCondition(condition types.Bool, diags *diag.Diagnostics) {
+	if condition.IsNull() && !m.isSet() {
+		diags.AddAttributeError(path.Root("condition"), "Missing condition",
+			"Either condition or at least one of the typed checks must be set.")
+	}
+}
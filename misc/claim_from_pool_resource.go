@@ -2,6 +2,7 @@ package misc
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -191,25 +192,30 @@ func (r *claimFromPool) update(ctx context.Context, tfplan tfsdk.Plan, tfstate t
 
 	freePool := make([]string, len(planPool))
 	copy(freePool, planPool)
-	notYetClaimers := make([]string, len(planClaimers))
-	copy(notYetClaimers, planClaimers)
 
-	for c, p := range stateOutput {
-		if !stringInSlice(c, planClaimers) || !stringInSlice(p, planPool) {
-			delete(stateOutput, c)
-		}
-	}
-
-	for c, p := range stateOutput {
-		notYetClaimers = deleteFromSlice(notYetClaimers, c)
-		freePool = deleteFromSlice(freePool, p)
-	}
-
-	for k, c := range notYetClaimers {
-		stateOutput[c] = freePool[k]
+	claims := assignClaims(planClaimers, stateOutput,
+		func(_ string, p string) bool {
+			if !stringInSlice(p, freePool) {
+				return false
+			}
+			freePool = deleteFromSlice(freePool, p)
+			return true
+		},
+		func(c string) (string, bool) {
+			if len(freePool) == 0 {
+				diag.AddAttributeError(path.Root("claimers"), "Not enough items in the pool",
+					fmt.Sprintf("There is no free item left in the pool for %q.", c))
+				return "", false
+			}
+			p := freePool[0]
+			freePool = freePool[1:]
+			return p, true
+		})
+	if diag.HasError() {
+		return
 	}
 
-	mv, diags := basetypes.NewMapValueFrom(ctx, types.StringType, stateOutput)
+	mv, diags := basetypes.NewMapValueFrom(ctx, types.StringType, claims)
 	diag.Append(diags...)
 	if diag.HasError() {
 		return
@@ -0,0 +1,176 @@
+package misc
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &counter{}
+	_ resource.ResourceWithModifyPlan = &counter{}
+)
+
+// NewCounterResource is a helper function to simplify the provider implementation.
+func NewCounterResource() resource.Resource {
+	return &counter{}
+}
+
+// counter is the resource implementation.
+type counter struct{}
+
+// counterModel maps the resource schema data.
+type counterModel struct {
+	ID               basetypes.StringValue `tfsdk:"id"`
+	Triggers         basetypes.MapValue    `tfsdk:"triggers"`
+	IncrementOnApply basetypes.BoolValue   `tfsdk:"increment_on_apply"`
+	InitialValue     basetypes.Int64Value  `tfsdk:"initial_value"`
+	Value            basetypes.Int64Value  `tfsdk:"value"`
+}
+
+// Metadata returns the resource type name.
+func (r *counter) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_counter"
+}
+
+// Schema defines the schema for the resource.
+func (r *counter) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Monotonic counter incremented whenever the triggers change. The value never decreases.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Random id.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Description: "Arbitrary map of values, the counter is incremented whenever it changes.",
+				Optional:    true,
+			},
+			"increment_on_apply": schema.BoolAttribute{
+				Description: "Increment the counter on every apply regardless of the triggers.",
+				Optional:    true,
+			},
+			"initial_value": schema.Int64Attribute{
+				Description: "Value of the counter when created. Defaults to 0.",
+				Optional:    true,
+			},
+			"value": schema.Int64Attribute{
+				Description: "Current value of the counter.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *counter) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan, the value is already computed by ModifyPlan
+	var plan counterModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.ID = types.StringValue(time.Now().Format(time.RFC3339Nano))
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *counter) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	return
+}
+
+func (r *counter) update(ctx context.Context, tfplan tfsdk.Plan, tfstate tfsdk.State, diag *diag.Diagnostics) (plan counterModel) {
+	diags := tfplan.Get(ctx, &plan)
+	diag.Append(diags...)
+	if diag.HasError() {
+		return
+	}
+
+	if tfstate.Raw.IsNull() {
+		plan.Value = types.Int64Value(plan.InitialValue.ValueInt64())
+		if plan.InitialValue.IsUnknown() {
+			plan.Value = types.Int64Unknown()
+		}
+		return
+	}
+
+	var state counterModel
+	diags = tfstate.Get(ctx, &state)
+	diag.Append(diags...)
+	if diag.HasError() {
+		return
+	}
+
+	// unknown triggers may still turn out unchanged, the final plan during apply decides
+	if containsUnknown(plan.Triggers) || plan.IncrementOnApply.IsUnknown() {
+		plan.Value = types.Int64Unknown()
+		return
+	}
+
+	plan.Value = state.Value
+	if plan.IncrementOnApply.ValueBool() || !plan.Triggers.Equal(state.Triggers) {
+		plan.Value = types.Int64Value(state.Value.ValueInt64() + 1)
+	}
+
+	return
+}
+
+// ModifyPlan modifies plan in a way to show all the changes before the apply
+func (r *counter) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+
+	// don't modify on delete
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	plan := r.update(ctx, req.Plan, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *counter) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan counterModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *counter) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	return
+}
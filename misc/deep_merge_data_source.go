@@ -0,0 +1,254 @@
+package misc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	listStrategyReplace    = "replace"
+	listStrategyAppend     = "append"
+	listStrategyUnionByKey = "union_by_key"
+
+	nullStrategyKeep   = "keep"
+	nullStrategyIgnore = "ignore"
+	nullStrategyDelete = "delete"
+)
+
+var _ datasource.DataSource = &DeepMergeDataSource{}
+
+func NewDeepMergeDataSource() datasource.DataSource {
+	return &DeepMergeDataSource{}
+}
+
+// DeepMergeDataSource defines the data source implementation.
+type DeepMergeDataSource struct{}
+
+// DeepMergeDataSourceModel describes the data source data model.
+type DeepMergeDataSourceModel struct {
+	Id           types.String `tfsdk:"id"`
+	Inputs       types.List   `tfsdk:"inputs"`
+	ListStrategy types.String `tfsdk:"list_strategy"`
+	MergeKey     types.String `tfsdk:"merge_key"`
+	NullStrategy types.String `tfsdk:"null_strategy"`
+	Result       types.String `tfsdk:"result"`
+}
+
+func (d *DeepMergeDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_deep_merge"
+}
+
+func (d *DeepMergeDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Deep merges JSON documents, later documents take precedence. " +
+			"Objects are merged recursively, other values are replaced. " +
+			"Use `jsonencode` for the inputs and `jsondecode` for the result",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Deep merge identifier",
+				Computed:            true,
+			},
+			"inputs": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "JSON documents to merge, e.g. global, region, environment and service defaults",
+				Required:            true,
+			},
+			"list_strategy": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("How lists are merged, one of `%s`, `%s` and `%s`. Defaults to `%s`",
+					listStrategyReplace, listStrategyAppend, listStrategyUnionByKey, listStrategyReplace),
+				Optional: true,
+			},
+			"merge_key": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Key identifying objects in lists merged by `%s`, objects with the same value "+
+					"of the key are merged, other elements are appended", listStrategyUnionByKey),
+				Optional: true,
+			},
+			"null_strategy": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("How nulls in object keys are handled: `%s` sets the null, `%s` keeps "+
+					"the previous value if any and `%s` removes the object key. Defaults to `%s`",
+					nullStrategyKeep, nullStrategyIgnore, nullStrategyDelete, nullStrategyKeep),
+				Optional: true,
+			},
+			"result": schema.StringAttribute{
+				MarkdownDescription: "Merged JSON document",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *DeepMergeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DeepMergeDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	inputs := []string{}
+	resp.Diagnostics.Append(data.Inputs.ElementsAs(ctx, &inputs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	m := deepMerge{listStrategy: listStrategyReplace, mergeKey: data.MergeKey.ValueString(), nullStrategy: nullStrategyKeep}
+	if !data.ListStrategy.IsNull() {
+		m.listStrategy = data.ListStrategy.ValueString()
+	}
+	if !data.NullStrategy.IsNull() {
+		m.nullStrategy = data.NullStrategy.ValueString()
+	}
+	if !stringInSlice(m.listStrategy, []string{listStrategyReplace, listStrategyAppend, listStrategyUnionByKey}) {
+		resp.Diagnostics.AddAttributeError(path.Root("list_strategy"), "Invalid list strategy",
+			fmt.Sprintf("List strategy must be one of %s, %s and %s, got %q.",
+				listStrategyReplace, listStrategyAppend, listStrategyUnionByKey, m.listStrategy))
+	}
+	if m.listStrategy == listStrategyUnionByKey && m.mergeKey == "" {
+		resp.Diagnostics.AddAttributeError(path.Root("merge_key"), "Missing merge key",
+			fmt.Sprintf("Merge key must be set with the %s list strategy.", listStrategyUnionByKey))
+	}
+	if !stringInSlice(m.nullStrategy, []string{nullStrategyKeep, nullStrategyIgnore, nullStrategyDelete}) {
+		resp.Diagnostics.AddAttributeError(path.Root("null_strategy"), "Invalid null strategy",
+			fmt.Sprintf("Null strategy must be one of %s, %s and %s, got %q.",
+				nullStrategyKeep, nullStrategyIgnore, nullStrategyDelete, m.nullStrategy))
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var result interface{}
+	for i, input := range inputs {
+		v, err := decodeJSON(input)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("inputs").AtListIndex(i), "Invalid JSON", err.Error())
+			continue
+		}
+		// the first document is merged into nothing too, so the null strategy applies to it
+		result = m.merge(result, v)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r, err := encodeJSON(result)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to encode the result", err.Error())
+		return
+	}
+
+	data.Id = types.StringValue(time.Now().Format(time.RFC3339Nano))
+	data.Result = types.StringValue(r)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// deepMerge merges decoded JSON values using the configured strategies.
+type deepMerge struct {
+	listStrategy string
+	mergeKey     string
+	nullStrategy string
+}
+
+// merge returns dst with src merged into it, dst may be modified.
+func (m deepMerge) merge(dst, src interface{}) interface{} {
+	switch s := src.(type) {
+	case map[string]interface{}:
+		d, ok := dst.(map[string]interface{})
+		if !ok {
+			return m.strip(s)
+		}
+		for k, v := range s {
+			if v == nil {
+				switch m.nullStrategy {
+				case nullStrategyIgnore:
+					continue
+				case nullStrategyDelete:
+					delete(d, k)
+					continue
+				}
+			}
+			if dv, ok := d[k]; ok {
+				d[k] = m.merge(dv, v)
+			} else {
+				d[k] = m.strip(v)
+			}
+		}
+		return d
+	case []interface{}:
+		d, ok := dst.([]interface{})
+		if !ok || m.listStrategy == listStrategyReplace {
+			return m.strip(s)
+		}
+		if m.listStrategy == listStrategyAppend {
+			return append(d, m.strip(s).([]interface{})...)
+		}
+		for _, v := range s {
+			i := m.indexByKey(d, v)
+			if i < 0 {
+				d = append(d, m.strip(v))
+			} else {
+				d[i] = m.merge(d[i], v)
+			}
+		}
+		return d
+	case nil:
+		if m.nullStrategy == nullStrategyIgnore {
+			return dst
+		}
+		return nil
+	default:
+		return src
+	}
+}
+
+// strip removes the nulls from the object keys of v when they would be dropped by merging, as if v was merged into nothing.
+func (m deepMerge) strip(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		return m.merge(map[string]interface{}{}, t)
+	case []interface{}:
+		l := make([]interface{}, len(t))
+		for i, e := range t {
+			l[i] = m.strip(e)
+		}
+		return l
+	default:
+		return v
+	}
+}
+
+// indexByKey returns the index of the object in list with the same merge key value as v, or -1.
+func (m deepMerge) indexByKey(list []interface{}, v interface{}) int {
+	key, ok := m.keyOf(v)
+	if !ok {
+		return -1
+	}
+	for i, e := range list {
+		if k, ok := m.keyOf(e); ok && k == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func (m deepMerge) keyOf(v interface{}) (string, bool) {
+	o, ok := v.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	k, ok := o[m.mergeKey]
+	if !ok {
+		return "", false
+	}
+	s, err := encodeJSON(k)
+	if err != nil {
+		return "", false
+	}
+	return s, true
+}
@@ -0,0 +1,112 @@
+package misc
+
+import "testing"
+
+func TestDeepMerge(t *testing.T) {
+	cases := []struct {
+		name         string
+		inputs       []string
+		listStrategy string
+		mergeKey     string
+		nullStrategy string
+		want         string
+	}{
+		{
+			name:   "replacing lists",
+			inputs: []string{`{"a":[1,2],"b":{"c":1}}`, `{"a":[3],"b":{"d":2}}`},
+			want:   `{"a":[3],"b":{"c":1,"d":2}}`,
+		},
+		{
+			name:         "appending lists",
+			inputs:       []string{`{"a":[1,2],"b":{"c":1}}`, `{"a":[3],"b":{"d":2}}`},
+			listStrategy: listStrategyAppend,
+			want:         `{"a":[1,2,3],"b":{"c":1,"d":2}}`,
+		},
+		{
+			name: "merging lists by key",
+			inputs: []string{
+				`{"l":[{"name":"x","v":1},{"name":"y","v":2},3]}`,
+				`{"l":[{"name":"y","v":3,"w":true},{"name":"z"},3]}`,
+			},
+			listStrategy: listStrategyUnionByKey,
+			mergeKey:     "name",
+			want:         `{"l":[{"name":"x","v":1},{"name":"y","v":3,"w":true},3,{"name":"z"},3]}`,
+		},
+		{
+			name:   "replacing values of another type",
+			inputs: []string{`{"a":{"b":1},"c":[1],"d":"x"}`, `{"a":[1],"c":{"e":1},"d":{"f":2}}`},
+			want:   `{"a":[1],"c":{"e":1},"d":{"f":2}}`,
+		},
+		{
+			name:   "keeping nulls",
+			inputs: []string{`{"a":null,"b":{"c":null},"g":1}`, `{"b":{"d":null},"e":null,"g":null}`},
+			want:   `{"a":null,"b":{"c":null,"d":null},"e":null,"g":null}`,
+		},
+		{
+			name:         "ignoring nulls",
+			inputs:       []string{`{"a":1,"b":{"c":null},"n":null}`, `{"a":null,"b":{"c":2,"d":null},"e":{"f":null}}`},
+			nullStrategy: nullStrategyIgnore,
+			want:         `{"a":1,"b":{"c":2},"e":{}}`,
+		},
+		{
+			name:         "deleting nulls",
+			inputs:       []string{`{"a":1,"b":{"c":null,"d":1},"n":null}`, `{"a":null,"b":{"d":null,"g":2},"e":{"f":null}}`},
+			nullStrategy: nullStrategyDelete,
+			want:         `{"b":{"g":2},"e":{}}`,
+		},
+		{
+			name:         "deleting nulls of a single document",
+			inputs:       []string{`{"a":null,"b":{"c":null},"l":[null,{"d":null}]}`},
+			nullStrategy: nullStrategyDelete,
+			want:         `{"b":{},"l":[null,{}]}`,
+		},
+		{
+			name:         "deleting nulls of appended list elements",
+			inputs:       []string{`{"l":[{"a":1}]}`, `{"l":[{"b":null}]}`},
+			listStrategy: listStrategyAppend,
+			nullStrategy: nullStrategyDelete,
+			want:         `{"l":[{"a":1},{}]}`,
+		},
+		{
+			name:         "ignoring a null document",
+			inputs:       []string{`{"a":1}`, `null`},
+			nullStrategy: nullStrategyIgnore,
+			want:         `{"a":1}`,
+		},
+		{
+			name:   "keeping a null document",
+			inputs: []string{`{"a":1}`, `null`},
+			want:   `null`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := deepMerge{listStrategy: listStrategyReplace, mergeKey: c.mergeKey, nullStrategy: nullStrategyKeep}
+			if c.listStrategy != "" {
+				m.listStrategy = c.listStrategy
+			}
+			if c.nullStrategy != "" {
+				m.nullStrategy = c.nullStrategy
+			}
+
+			var got interface{}
+			for _, input := range c.inputs {
+				v, err := decodeJSON(input)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = m.merge(got, v)
+			}
+
+			want, err := decodeJSON(c.want)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !jsonEqual(got, want) {
+				result, _ := encodeJSON(got)
+				t.Errorf("expected %s, got %s", c.want, result)
+			}
+		})
+	}
+}
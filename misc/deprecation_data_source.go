@@ -0,0 +1,132 @@
+package misc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &DeprecationDataSource{}
+
+func NewDeprecationDataSource() datasource.DataSource {
+	return &DeprecationDataSource{}
+}
+
+// DeprecationDataSource defines the data source implementation.
+type DeprecationDataSource struct{}
+
+// DeprecationDataSourceModel describes the data source data model.
+type DeprecationDataSourceModel struct {
+	Id             types.String `tfsdk:"id"`
+	Module         types.String `tfsdk:"module"`
+	Feature        types.String `tfsdk:"feature"`
+	RemovalVersion types.String `tfsdk:"removal_version"`
+	CurrentVersion types.String `tfsdk:"current_version"`
+	Replacement    types.String `tfsdk:"replacement"`
+	Used           types.Bool   `tfsdk:"used"`
+	Removed        types.Bool   `tfsdk:"removed"`
+	Message        types.String `tfsdk:"message"`
+}
+
+func (d *DeprecationDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_deprecation"
+}
+
+func (d *DeprecationDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Emits a standardized deprecation warning, or an error once the current module version " +
+			"reached the removal version",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Deprecation identifier",
+				Computed:            true,
+			},
+			"module": schema.StringAttribute{
+				MarkdownDescription: "Name of the module",
+				Required:            true,
+			},
+			"feature": schema.StringAttribute{
+				MarkdownDescription: "Deprecated input or feature, e.g. `var.subnet_id`",
+				Required:            true,
+			},
+			"removal_version": schema.StringAttribute{
+				MarkdownDescription: "Module version in which the feature is removed",
+				Required:            true,
+			},
+			"current_version": schema.StringAttribute{
+				MarkdownDescription: "Current module version",
+				Required:            true,
+			},
+			"replacement": schema.StringAttribute{
+				MarkdownDescription: "Input or feature to use instead",
+				Optional:            true,
+			},
+			"used": schema.BoolAttribute{
+				MarkdownDescription: "Whether the deprecated feature is used, nothing is reported when false. Defaults to `true`",
+				Optional:            true,
+			},
+			"removed": schema.BoolAttribute{
+				MarkdownDescription: "Whether the current version reached the removal version",
+				Computed:            true,
+			},
+			"message": schema.StringAttribute{
+				MarkdownDescription: "Deprecation message",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *DeprecationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DeprecationDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	removalVersion, err := version.NewVersion(data.RemovalVersion.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("removal_version"), "Invalid removal version", err.Error())
+	}
+	currentVersion, err := version.NewVersion(data.CurrentVersion.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("current_version"), "Invalid current version", err.Error())
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	removed := currentVersion.GreaterThanOrEqual(removalVersion)
+	var message string
+	if removed {
+		message = fmt.Sprintf("Module %s: %s was removed in version %s, the current version is %s.",
+			data.Module.ValueString(), data.Feature.ValueString(), removalVersion.Original(), currentVersion.Original())
+	} else {
+		message = fmt.Sprintf("Module %s: %s is deprecated and will be removed in version %s.",
+			data.Module.ValueString(), data.Feature.ValueString(), removalVersion.Original())
+	}
+	if !data.Replacement.IsNull() {
+		message += fmt.Sprintf(" Use %s instead.", data.Replacement.ValueString())
+	}
+
+	if data.Used.IsNull() || data.Used.ValueBool() {
+		if removed {
+			resp.Diagnostics.AddError(fmt.Sprintf("%s was removed", data.Feature.ValueString()), message)
+		} else {
+			resp.Diagnostics.AddWarning(fmt.Sprintf("%s is deprecated", data.Feature.ValueString()), message)
+		}
+	}
+
+	data.Id = types.StringValue(time.Now().Format(time.RFC3339Nano))
+	data.Removed = types.BoolValue(removed)
+	data.Message = types.StringValue(message)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,163 @@
+package misc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource                   = &DiagnosticDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &DiagnosticDataSource{}
+)
+
+func NewErrorDataSource() datasource.DataSource {
+	return &DiagnosticDataSource{typeName: "error", defaultSeverity: severityError}
+}
+
+func NewWarningDataSource() datasource.DataSource {
+	return &DiagnosticDataSource{typeName: "warning", defaultSeverity: severityWarning}
+}
+
+// DiagnosticDataSource defines the data source implementation shared by misc_error and misc_warning,
+// which only differ in the default severity.
+type DiagnosticDataSource struct {
+	typeName        string
+	defaultSeverity string
+}
+
+// DiagnosticDataSourceModel describes the data source data model.
+type DiagnosticDataSourceModel struct {
+	Id        types.String `tfsdk:"id"`
+	Condition types.Bool   `tfsdk:"condition"`
+	Severity  types.String `tfsdk:"severity"`
+	Summary   types.String `tfsdk:"summary"`
+	Details   types.String `tfsdk:"details"`
+	Vars      types.Map    `tfsdk:"vars"`
+	Subject   types.String `tfsdk:"subject"`
+
+	RegexMatch          types.Object `tfsdk:"regex_match"`
+	CidrsNonOverlapping types.List   `tfsdk:"cidrs_non_overlapping"`
+	SemverSatisfies     types.Object `tfsdk:"semver_satisfies"`
+	UniqueValues        types.List   `tfsdk:"unique_values"`
+	SubsetOf            types.Object `tfsdk:"subset_of"`
+	WithinRange         types.Object `tfsdk:"within_range"`
+}
+
+func (m DiagnosticDataSourceModel) checkKinds() checkKindsModel {
+	return checkKindsModel{
+		RegexMatch:          m.RegexMatch,
+		CidrsNonOverlapping: m.CidrsNonOverlapping,
+		SemverSatisfies:     m.SemverSatisfies,
+		UniqueValues:        m.UniqueValues,
+		SubsetOf:            m.SubsetOf,
+		WithinRange:         m.WithinRange,
+	}
+}
+
+func (d *DiagnosticDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + d.typeName
+}
+
+func (d *DiagnosticDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: fmt.Sprintf("Emits a diagnostic of `%s` severity by default "+
+			"when the condition is false or any of the typed checks fails. "+
+			"When the checked values are unknown during plan, Terraform defers reading the data source "+
+			"and the checks are evaluated during apply", d.defaultSeverity),
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Check identifier",
+				Computed:            true,
+			},
+			"condition": schema.BoolAttribute{
+				MarkdownDescription: "Check condition, the check fails when it is false. Required unless one of the typed checks is set",
+				Optional:            true,
+			},
+			"severity": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Severity of the diagnostic, `error`, `warning` or `info` (only logged). Defaults to `%s`", d.defaultSeverity),
+				Optional:            true,
+			},
+			"summary": schema.StringAttribute{
+				MarkdownDescription: "Message summary",
+				Required:            true,
+			},
+			"details": schema.StringAttribute{
+				MarkdownDescription: "Message details",
+				Optional:            true,
+			},
+			"vars": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Variables available in `summary` and `details`, which are rendered as Go templates (e.g. `{{ .name }}`) only when `vars` is set",
+				Optional:            true,
+			},
+			"subject": schema.StringAttribute{
+				MarkdownDescription: "Attribute path the failure is about, e.g. `vars.cidr` or `regex_match.values[3]`. " +
+					"Paths outside of this data source (e.g. `var.cidrs[3]`) are reported against this attribute",
+				Optional: true,
+			},
+		},
+	}
+
+	for name, attribute := range checkKindsAttributes() {
+		resp.Schema.Attributes[name] = attribute
+	}
+}
+
+func (d *DiagnosticDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data DiagnosticDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateSeverity(path.Root("severity"), data.Severity, &resp.Diagnostics)
+	validateSubject(path.Root("subject"), data.Subject, &resp.Diagnostics)
+	data.checkKinds().validateCondition(data.Condition, &resp.Diagnostics)
+}
+
+func (d *DiagnosticDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DiagnosticDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	failures := data.checkKinds().evaluate(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	severity := d.defaultSeverity
+	if !data.Severity.IsNull() {
+		severity = data.Severity.ValueString()
+	}
+
+	conditionFailed := !data.Condition.IsNull() && !data.Condition.ValueBool()
+	if conditionFailed || len(failures) > 0 {
+		summary, details := renderMessage(ctx, path.Empty(), data.Summary, data.Details, data.Vars, &resp.Diagnostics)
+		subject, note := resolveSubject(data.Subject, func(name string) bool {
+			_, ok := req.Config.Schema.GetAttributes()[name]
+			return ok
+		}, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if conditionFailed {
+			addCheckDiagnostic(ctx, &resp.Diagnostics, subject, severity, summary, joinDetails(details, note))
+		}
+		for _, f := range failures {
+			addCheckDiagnostic(ctx, &resp.Diagnostics, subject, severity, summary, joinDetails(f, details, note))
+		}
+	}
+
+	data.Id = types.StringValue(time.Now().Format(time.RFC3339Nano))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,356 @@
+package misc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// diffContext is the number of unchanged lines around the changes in the unified diff.
+	diffContext = 3
+
+	// maxDiffCells bounds the size of the longest common subsequence table of the changed lines,
+	// larger changes are diffed coarsely.
+	maxDiffCells = 1 << 22
+)
+
+var _ datasource.DataSource = &DiffDataSource{}
+
+func NewDiffDataSource() datasource.DataSource {
+	return &DiffDataSource{}
+}
+
+// DiffDataSource defines the data source implementation.
+type DiffDataSource struct{}
+
+// DiffDataSourceModel describes the data source data model.
+type DiffDataSourceModel struct {
+	Id      types.String `tfsdk:"id"`
+	Old     types.String `tfsdk:"old"`
+	New     types.String `tfsdk:"new"`
+	Added   types.List   `tfsdk:"added"`
+	Removed types.List   `tfsdk:"removed"`
+	Changed types.List   `tfsdk:"changed"`
+	Equal   types.Bool   `tfsdk:"equal"`
+	Diff    types.String `tfsdk:"diff"`
+}
+
+func (d *DiffDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_diff"
+}
+
+func (d *DiffDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Compares two JSON documents and returns the JSON pointers of the added, removed and changed values, " +
+			"plus a unified diff. Objects and arrays are compared member by member, arrays by index",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Diff identifier",
+				Computed:            true,
+			},
+			"old": schema.StringAttribute{
+				MarkdownDescription: "Old JSON document",
+				Required:            true,
+			},
+			"new": schema.StringAttribute{
+				MarkdownDescription: "New JSON document",
+				Required:            true,
+			},
+			"added": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "JSON pointers of the values only in the new document, sorted",
+				Computed:            true,
+			},
+			"removed": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "JSON pointers of the values only in the old document, sorted",
+				Computed:            true,
+			},
+			"changed": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "JSON pointers of the values which differ, sorted",
+				Computed:            true,
+			},
+			"equal": schema.BoolAttribute{
+				MarkdownDescription: "Whether the documents are equal",
+				Computed:            true,
+			},
+			"diff": schema.StringAttribute{
+				MarkdownDescription: "Unified diff of the indented documents, empty if they are equal. Very large changes are shown as removing all the changed lines and adding the new ones",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *DiffDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DiffDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	oldDoc, err := decodeJSON(data.Old.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("old"), "Invalid JSON", err.Error())
+	}
+	newDoc, err := decodeJSON(data.New.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("new"), "Invalid JSON", err.Error())
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	c := jsonChanges{added: []string{}, removed: []string{}, changed: []string{}}
+	c.compare(oldDoc, newDoc, []string{})
+	sort.Strings(c.added)
+	sort.Strings(c.removed)
+	sort.Strings(c.changed)
+
+	oldText, err := encodeJSONIndent(oldDoc)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("old"), "Failed to encode the document", err.Error())
+	}
+	newText, err := encodeJSONIndent(newDoc)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("new"), "Failed to encode the document", err.Error())
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	av, diags := types.ListValueFrom(ctx, types.StringType, c.added)
+	resp.Diagnostics.Append(diags...)
+	rv, diags := types.ListValueFrom(ctx, types.StringType, c.removed)
+	resp.Diagnostics.Append(diags...)
+	cv, diags := types.ListValueFrom(ctx, types.StringType, c.changed)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(time.Now().Format(time.RFC3339Nano))
+	data.Added = av
+	data.Removed = rv
+	data.Changed = cv
+	data.Equal = types.BoolValue(len(c.added)+len(c.removed)+len(c.changed) == 0)
+	data.Diff = types.StringValue("")
+	if !data.Equal.ValueBool() {
+		data.Diff = types.StringValue(unifiedDiff("old", "new", oldText, newText))
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// jsonChanges collects the JSON pointers of the differences between two decoded JSON values.
+type jsonChanges struct {
+	added   []string
+	removed []string
+	changed []string
+}
+
+func (c *jsonChanges) compare(a, b interface{}, at []string) {
+	child := func(token string) []string {
+		return append(at[:len(at):len(at)], token)
+	}
+
+	switch av := a.(type) {
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok {
+			break
+		}
+		for _, k := range sortedKeys(av) {
+			if _, ok := bv[k]; ok {
+				c.compare(av[k], bv[k], child(k))
+			} else {
+				c.removed = append(c.removed, formatJSONPointer(child(k)))
+			}
+		}
+		for _, k := range sortedKeys(bv) {
+			if _, ok := av[k]; !ok {
+				c.added = append(c.added, formatJSONPointer(child(k)))
+			}
+		}
+		return
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok {
+			break
+		}
+		for i := 0; i < len(av) || i < len(bv); i++ {
+			switch {
+			case i >= len(bv):
+				c.removed = append(c.removed, formatJSONPointer(child(strconv.Itoa(i))))
+			case i >= len(av):
+				c.added = append(c.added, formatJSONPointer(child(strconv.Itoa(i))))
+			default:
+				c.compare(av[i], bv[i], child(strconv.Itoa(i)))
+			}
+		}
+		return
+	}
+
+	if !jsonEqual(a, b) {
+		c.changed = append(c.changed, formatJSONPointer(at))
+	}
+}
+
+// unifiedDiff returns the unified diff of two texts, empty if they are equal.
+func unifiedDiff(oldName, newName, oldText, newText string) string {
+	ops := diffLines(strings.Split(oldText, "\n"), strings.Split(newText, "\n"))
+
+	changes := []int{}
+	for i, op := range ops {
+		if op.kind != ' ' {
+			changes = append(changes, i)
+		}
+	}
+	if len(changes) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", oldName, newName)
+	for i := 0; i < len(changes); {
+		// extend the hunk while the next change is close enough to share the context
+		j := i
+		for j+1 < len(changes) && changes[j+1]-changes[j]-1 <= 2*diffContext {
+			j++
+		}
+		start := changes[i] - diffContext
+		if start < 0 {
+			start = 0
+		}
+		end := changes[j] + diffContext + 1
+		if end > len(ops) {
+			end = len(ops)
+		}
+
+		oldStart, newStart := 0, 0
+		for _, op := range ops[:start] {
+			if op.kind != '+' {
+				oldStart++
+			}
+			if op.kind != '-' {
+				newStart++
+			}
+		}
+		oldLen, newLen := 0, 0
+		for _, op := range ops[start:end] {
+			if op.kind != '+' {
+				oldLen++
+			}
+			if op.kind != '-' {
+				newLen++
+			}
+		}
+		// ranges are 1-based unless empty, when they point at the line before
+		if oldLen > 0 {
+			oldStart++
+		}
+		if newLen > 0 {
+			newStart++
+		}
+
+		fmt.Fprintf(&b, "@@ -%s +%s @@\n", hunkRange(oldStart, oldLen), hunkRange(newStart, newLen))
+		for _, op := range ops[start:end] {
+			fmt.Fprintf(&b, "%c%s\n", op.kind, op.line)
+		}
+		i = j + 1
+	}
+	return b.String()
+}
+
+// hunkRange formats a hunk range like GNU diff, omitting the length of a single line.
+func hunkRange(start, length int) string {
+	if length == 1 {
+		return strconv.Itoa(start)
+	}
+	return fmt.Sprintf("%d,%d", start, length)
+}
+
+// diffOp is a line of a diff, kind is ' ' for an unchanged line, '-' for a removed one and '+' for an added one.
+type diffOp struct {
+	kind byte
+	line string
+}
+
+// diffLines returns the operations turning a into b, based on their longest common subsequence.
+// When the changed lines are too many to compare each with each, they are all removed and added instead.
+func diffLines(a, b []string) []diffOp {
+	// the common prefix and suffix are unchanged, which keeps the table small for typical changes
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	am, bm := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+
+	ops := []diffOp{}
+	for _, l := range a[:prefix] {
+		ops = append(ops, diffOp{' ', l})
+	}
+
+	if (len(am)+1)*(len(bm)+1) > maxDiffCells {
+		for _, l := range am {
+			ops = append(ops, diffOp{'-', l})
+		}
+		for _, l := range bm {
+			ops = append(ops, diffOp{'+', l})
+		}
+		for _, l := range a[len(a)-suffix:] {
+			ops = append(ops, diffOp{' ', l})
+		}
+		return ops
+	}
+
+	// lcs[i*w+j] is the length of the longest common subsequence of am[i:] and bm[j:]
+	w := len(bm) + 1
+	lcs := make([]int32, (len(am)+1)*w)
+	for i := len(am) - 1; i >= 0; i-- {
+		for j := len(bm) - 1; j >= 0; j-- {
+			if am[i] == bm[j] {
+				lcs[i*w+j] = lcs[(i+1)*w+j+1] + 1
+			} else if lcs[(i+1)*w+j] >= lcs[i*w+j+1] {
+				lcs[i*w+j] = lcs[(i+1)*w+j]
+			} else {
+				lcs[i*w+j] = lcs[i*w+j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(am) || j < len(bm) {
+		switch {
+		case i < len(am) && j < len(bm) && am[i] == bm[j]:
+			ops = append(ops, diffOp{' ', am[i]})
+			i++
+			j++
+		case j == len(bm) || (i < len(am) && lcs[(i+1)*w+j] >= lcs[i*w+j+1]):
+			ops = append(ops, diffOp{'-', am[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', bm[j]})
+			j++
+		}
+	}
+	for _, l := range a[len(a)-suffix:] {
+		ops = append(ops, diffOp{' ', l})
+	}
+	return ops
+}
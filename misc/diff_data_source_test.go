@@ -0,0 +1,215 @@
+package misc
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	cases := []struct {
+		name string
+		old  string
+		new  string
+		want string
+	}{
+		{
+			name: "equal texts",
+			old:  "a\nb",
+			new:  "a\nb",
+			want: "",
+		},
+		{
+			name: "single change",
+			old:  "l1\nl2\nl3\nl4\nl5\nl6\nl7\nl8\nl9\nl10",
+			new:  "l1\nl2\nl3\nl4\nL5\nl6\nl7\nl8\nl9\nl10",
+			want: `--- old
++++ new
+@@ -2,7 +2,7 @@
+ l2
+ l3
+ l4
+-l5
++L5
+ l6
+ l7
+ l8
+`,
+		},
+		{
+			name: "changes far apart",
+			old:  "l1\nl2\nl3\nl4\nl5\nl6\nl7\nl8\nl9\nl10\nl11\nl12\nl13\nl14\nl15\nl16\nl17\nl18\nl19\nl20",
+			new:  "l1\nL2\nl3\nl4\nl5\nl6\nl7\nl8\nl9\nl10\nl11\nl12\nl13\nl14\nl15\nl16\nl17\nL18\nl19\nl20",
+			want: `--- old
++++ new
+@@ -1,5 +1,5 @@
+ l1
+-l2
++L2
+ l3
+ l4
+ l5
+@@ -15,6 +15,6 @@
+ l15
+ l16
+ l17
+-l18
++L18
+ l19
+ l20
+`,
+		},
+		{
+			name: "changes sharing context",
+			old:  "l1\nl2\nl3\nl4\nl5\nl6\nl7\nl8\nl9\nl10\nl11\nl12\nl13\nl14\nl15",
+			new:  "l1\nl2\nl3\nL4\nl5\nl6\nl7\nl8\nl9\nl10\nL11\nl12\nl13\nl14\nl15",
+			want: `--- old
++++ new
+@@ -1,14 +1,14 @@
+ l1
+ l2
+ l3
+-l4
++L4
+ l5
+ l6
+ l7
+ l8
+ l9
+ l10
+-l11
++L11
+ l12
+ l13
+ l14
+`,
+		},
+		{
+			name: "changes with separate context",
+			old:  "l1\nl2\nl3\nl4\nl5\nl6\nl7\nl8\nl9\nl10\nl11\nl12\nl13\nl14\nl15\nl16",
+			new:  "l1\nl2\nl3\nL4\nl5\nl6\nl7\nl8\nl9\nl10\nl11\nL12\nl13\nl14\nl15\nl16",
+			want: `--- old
++++ new
+@@ -1,7 +1,7 @@
+ l1
+ l2
+ l3
+-l4
++L4
+ l5
+ l6
+ l7
+@@ -9,7 +9,7 @@
+ l9
+ l10
+ l11
+-l12
++L12
+ l13
+ l14
+ l15
+`,
+		},
+		{
+			name: "removal at the start and addition at the end",
+			old:  "l1\nl2\nl3\nl4\nl5",
+			new:  "l2\nl3\nl4\nl5\nl6",
+			want: `--- old
++++ new
+@@ -1,5 +1,5 @@
+-l1
+ l2
+ l3
+ l4
+ l5
++l6
+`,
+		},
+		{
+			name: "single line",
+			old:  "a",
+			new:  "b",
+			want: `--- old
++++ new
+@@ -1 +1 @@
+-a
++b
+`,
+		},
+		{
+			name: "insertion",
+			old:  "l1\nl2\nl3\nl4\nl5\nl6\nl7\nl8",
+			new:  "l1\nl2\nl3\nl4\nx\nl5\nl6\nl7\nl8",
+			want: `--- old
++++ new
+@@ -2,6 +2,7 @@
+ l2
+ l3
+ l4
++x
+ l5
+ l6
+ l7
+`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := unifiedDiff("old", "new", c.old, c.new); got != c.want {
+				t.Errorf("expected\n%s\ngot\n%s", c.want, got)
+			}
+		})
+	}
+}
+
+func TestDiffLinesCoarse(t *testing.T) {
+	// the changed lines are too many to compare each with each
+	n := 2100
+	a := []string{"same"}
+	b := []string{"same"}
+	for i := 0; i < n; i++ {
+		a = append(a, fmt.Sprintf("a%d", i))
+		b = append(b, fmt.Sprintf("b%d", i))
+	}
+	a = append(a, "end")
+	b = append(b, "end")
+
+	ops := diffLines(a, b)
+	if len(ops) != 2*n+2 {
+		t.Fatalf("expected %d operations, got %d", 2*n+2, len(ops))
+	}
+	if ops[0] != (diffOp{' ', "same"}) || ops[len(ops)-1] != (diffOp{' ', "end"}) {
+		t.Errorf("expected the common lines to be unchanged, got %v and %v", ops[0], ops[len(ops)-1])
+	}
+	for i := 0; i < n; i++ {
+		if want := (diffOp{'-', a[i+1]}); ops[1+i] != want {
+			t.Fatalf("expected %v at %d, got %v", want, 1+i, ops[1+i])
+		}
+		if want := (diffOp{'+', b[i+1]}); ops[1+n+i] != want {
+			t.Fatalf("expected %v at %d, got %v", want, 1+n+i, ops[1+n+i])
+		}
+	}
+}
+
+func TestJSONChanges(t *testing.T) {
+	oldDoc, err := decodeJSON(`{"a":1,"b":{"c":[1,2,3],"d":"x"},"e/f":true,"g":[]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newDoc, err := decodeJSON(`{"a":1.0,"b":{"c":[1,4],"d":{"x":1}},"h":null,"g":[0]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := jsonChanges{}
+	c.compare(oldDoc, newDoc, []string{})
+	want := jsonChanges{
+		added:   []string{"/g/0", "/h"},
+		removed: []string{"/b/c/2", "/e~1f"},
+		changed: []string{"/b/c/1", "/b/d"},
+	}
+	if !reflect.DeepEqual(c, want) {
+		t.Errorf("expected %+v, got %+v", want, c)
+	}
+}
@@ -0,0 +1,130 @@
+package misc
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// featureFlagBuckets is the number of buckets subjects are hashed into, percentages have a precision of 0.01.
+const featureFlagBuckets = 10000
+
+var _ datasource.DataSource = &FeatureFlagDataSource{}
+
+func NewFeatureFlagDataSource() datasource.DataSource {
+	return &FeatureFlagDataSource{}
+}
+
+// FeatureFlagDataSource defines the data source implementation.
+type FeatureFlagDataSource struct{}
+
+// FeatureFlagDataSourceModel describes the data source data model.
+type FeatureFlagDataSourceModel struct {
+	Id              types.String  `tfsdk:"id"`
+	Flag            types.String  `tfsdk:"flag"`
+	Percentage      types.Float64 `tfsdk:"percentage"`
+	Subjects        types.Set     `tfsdk:"subjects"`
+	Enabled         types.Map     `tfsdk:"enabled"`
+	EnabledSubjects types.List    `tfsdk:"enabled_subjects"`
+}
+
+func (d *FeatureFlagDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_feature_flag"
+}
+
+func (d *FeatureFlagDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Decides per subject whether a percentage feature flag is on. " +
+			"A subject is enabled when `bucket < round(percentage * 100)`, where `bucket` is the first 8 bytes " +
+			"of the SHA-256 of the flag, a NUL byte and the subject, read as a big-endian unsigned integer, " +
+			"modulo 10000. Raising the percentage therefore only adds subjects",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Feature flag identifier",
+				Computed:            true,
+			},
+			"flag": schema.StringAttribute{
+				MarkdownDescription: "Name of the flag, different flags enable different subjects",
+				Required:            true,
+			},
+			"percentage": schema.Float64Attribute{
+				MarkdownDescription: "Percentage of the subjects the flag is on for, between 0 and 100",
+				Required:            true,
+			},
+			"subjects": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Subject IDs, e.g. users or tenants",
+				Required:            true,
+			},
+			"enabled": schema.MapAttribute{
+				ElementType:         types.BoolType,
+				MarkdownDescription: "Map of subjects and whether the flag is on for them (subject => enabled)",
+				Computed:            true,
+			},
+			"enabled_subjects": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Subjects the flag is on for, sorted",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *FeatureFlagDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data FeatureFlagDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	subjects := []string{}
+	resp.Diagnostics.Append(data.Subjects.ElementsAs(ctx, &subjects, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	percentage := data.Percentage.ValueFloat64()
+	if percentage < 0 || percentage > 100 {
+		resp.Diagnostics.AddAttributeError(path.Root("percentage"), "Invalid percentage",
+			fmt.Sprintf("Percentage must be between 0 and 100, got %v.", percentage))
+		return
+	}
+
+	threshold := uint64(math.Round(percentage * featureFlagBuckets / 100))
+	enabled := map[string]bool{}
+	enabledSubjects := []string{}
+	for _, s := range subjects {
+		enabled[s] = featureFlagBucket(data.Flag.ValueString(), s) < threshold
+		if enabled[s] {
+			enabledSubjects = append(enabledSubjects, s)
+		}
+	}
+	sort.Strings(enabledSubjects)
+
+	ev, diags := types.MapValueFrom(ctx, types.BoolType, enabled)
+	resp.Diagnostics.Append(diags...)
+	sv, diags := types.ListValueFrom(ctx, types.StringType, enabledSubjects)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(time.Now().Format(time.RFC3339Nano))
+	data.Enabled = ev
+	data.EnabledSubjects = sv
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// featureFlagBucket returns the bucket of the subject, applications bucketing on their own must match it.
+func featureFlagBucket(flag, subject string) uint64 {
+	return hash64(flag, subject) % featureFlagBuckets
+}
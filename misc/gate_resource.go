@@ -0,0 +1,179 @@
+package misc
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &gate{}
+	_ resource.ResourceWithModifyPlan = &gate{}
+)
+
+// NewErrorGateResource is a helper function to simplify the provider implementation.
+func NewErrorGateResource() resource.Resource {
+	return &gate{severity: severityError}
+}
+
+// NewWarningGateResource is a helper function to simplify the provider implementation.
+func NewWarningGateResource() resource.Resource {
+	return &gate{severity: severityWarning}
+}
+
+// gate is the resource implementation, it fails the apply unless it's a warning gate.
+type gate struct {
+	severity string
+}
+
+// gateModel maps the resource schema data.
+type gateModel struct {
+	ID        basetypes.StringValue `tfsdk:"id"`
+	Condition basetypes.BoolValue   `tfsdk:"condition"`
+	Summary   basetypes.StringValue `tfsdk:"summary"`
+	Details   basetypes.StringValue `tfsdk:"details"`
+	Vars      basetypes.MapValue    `tfsdk:"vars"`
+	OnUnknown basetypes.StringValue `tfsdk:"on_unknown"`
+}
+
+// Metadata returns the resource type name.
+func (r *gate) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.severity + "_gate"
+}
+
+// Schema defines the schema for the resource.
+func (r *gate) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	description := "Fails the apply when the condition is false. " +
+		"The condition is evaluated during apply with the values of dependencies known, " +
+		"so resources depending on the gate are not touched when it fails."
+	if r.severity == severityWarning {
+		description = "Emits a warning during apply when the condition is false. " +
+			"The condition is evaluated during apply with the values of dependencies known."
+	}
+
+	resp.Schema = schema.Schema{
+		Description: description,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Random id.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"condition": schema.BoolAttribute{
+				Description: "Gate condition, the gate fails when it is false.",
+				Required:    true,
+			},
+			"summary": schema.StringAttribute{
+				Description: "Message summary.",
+				Required:    true,
+			},
+			"details": schema.StringAttribute{
+				Description: "Message details.",
+				Optional:    true,
+			},
+			"vars": schema.MapAttribute{
+				ElementType: types.StringType,
+				Description: "Variables available in summary and details, which are rendered as Go templates (e.g. {{ .name }}) only when vars is set.",
+				Optional:    true,
+			},
+			"on_unknown": onUnknownAttribute,
+		},
+	}
+}
+
+// check evaluates the gate condition and reports the failure.
+func (r *gate) check(ctx context.Context, plan gateModel, diags *diag.Diagnostics) {
+	if plan.Condition.ValueBool() {
+		return
+	}
+
+	summary, details := renderMessage(ctx, path.Empty(), plan.Summary, plan.Details, plan.Vars, diags)
+	if diags.HasError() {
+		return
+	}
+
+	addCheckDiagnostic(ctx, diags, path.Empty(), r.severity, summary, details)
+}
+
+// ModifyPlan reports the condition unknown during plan according to on_unknown.
+func (r *gate) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+
+	// don't check on delete
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan gateModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	handleUnknown(plan.OnUnknown, plan.Condition.IsUnknown(), &resp.Diagnostics)
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *gate) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan gateModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.check(ctx, plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.ID = types.StringValue(time.Now().Format(time.RFC3339Nano))
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *gate) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	return
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *gate) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan gateModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.check(ctx, plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *gate) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	return
+}
@@ -0,0 +1,200 @@
+package misc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// defaultVirtualNodes is the number of ring points per unit of bucket weight.
+	defaultVirtualNodes = 100
+
+	// maxVirtualNodes, maxBucketWeight and maxRingPoints bound the size of the ring, which is built in memory.
+	maxVirtualNodes = 1000
+	maxBucketWeight = 1000
+	maxRingPoints   = 1000000
+)
+
+var _ datasource.DataSource = &HashRingDataSource{}
+
+func NewHashRingDataSource() datasource.DataSource {
+	return &HashRingDataSource{}
+}
+
+// HashRingDataSource defines the data source implementation.
+type HashRingDataSource struct{}
+
+// HashRingDataSourceModel describes the data source data model.
+type HashRingDataSourceModel struct {
+	Id           types.String `tfsdk:"id"`
+	Keys         types.Set    `tfsdk:"keys"`
+	Buckets      types.Set    `tfsdk:"buckets"`
+	Weights      types.Map    `tfsdk:"weights"`
+	VirtualNodes types.Int64  `tfsdk:"virtual_nodes"`
+	Result       types.Map    `tfsdk:"result"`
+}
+
+func (d *HashRingDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_hash_ring"
+}
+
+func (d *HashRingDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Maps keys onto buckets using consistent hashing. " +
+			"Adding or removing a bucket moves only the keys of the affected bucket",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Hash ring identifier",
+				Computed:            true,
+			},
+			"keys": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Keys to map onto the buckets",
+				Required:            true,
+			},
+			"buckets": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Buckets the keys are mapped onto",
+				Required:            true,
+			},
+			"weights": schema.MapAttribute{
+				ElementType:         types.Int64Type,
+				MarkdownDescription: fmt.Sprintf("Relative weights of the buckets (bucket => weight), at most %d. Buckets not listed have weight 1", maxBucketWeight),
+				Optional:            true,
+			},
+			"virtual_nodes": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Number of points on the ring per unit of bucket weight, at most %d. Defaults to %d", maxVirtualNodes, defaultVirtualNodes),
+				Optional:            true,
+			},
+			"result": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Map of keys and their buckets (key => bucket)",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *HashRingDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data HashRingDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys := []string{}
+	buckets := []string{}
+	weights := map[string]int64{}
+	resp.Diagnostics.Append(data.Keys.ElementsAs(ctx, &keys, false)...)
+	resp.Diagnostics.Append(data.Buckets.ElementsAs(ctx, &buckets, false)...)
+	if !data.Weights.IsNull() {
+		resp.Diagnostics.Append(data.Weights.ElementsAs(ctx, &weights, false)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	virtualNodes := int64(defaultVirtualNodes)
+	if !data.VirtualNodes.IsNull() {
+		virtualNodes = data.VirtualNodes.ValueInt64()
+	}
+	if virtualNodes < 1 || virtualNodes > maxVirtualNodes {
+		resp.Diagnostics.AddAttributeError(path.Root("virtual_nodes"), "Invalid number of virtual nodes",
+			fmt.Sprintf("Number of virtual nodes must be between 1 and %d, got %d.", maxVirtualNodes, virtualNodes))
+		return
+	}
+
+	points := map[string]int64{}
+	total := int64(0)
+	for _, b := range buckets {
+		weight, ok := weights[b]
+		if !ok {
+			weight = 1
+		}
+		if weight < 0 || weight > maxBucketWeight {
+			resp.Diagnostics.AddAttributeError(path.Root("weights").AtMapKey(b), "Invalid weight",
+				fmt.Sprintf("Weight of %q must be between 0 and %d, got %d.", b, maxBucketWeight, weight))
+			continue
+		}
+		points[b] = weight * virtualNodes
+		total += points[b]
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if total > maxRingPoints {
+		resp.Diagnostics.AddAttributeError(path.Root("virtual_nodes"), "Hash ring too large",
+			fmt.Sprintf("The buckets would have %d points on the ring in total, at most %d are allowed. "+
+				"Lower virtual_nodes or the weights.", total, maxRingPoints))
+		return
+	}
+
+	ring := hashRing{}
+	for _, b := range buckets {
+		ring.add(b, points[b])
+	}
+	if len(ring) == 0 && len(keys) > 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("buckets"), "No buckets",
+			"At least one bucket with a positive weight is needed to map the keys.")
+		return
+	}
+	ring.sort()
+
+	result := map[string]string{}
+	for _, k := range keys {
+		result[k] = ring.get(k)
+	}
+
+	rv, diags := types.MapValueFrom(ctx, types.StringType, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(time.Now().Format(time.RFC3339Nano))
+	data.Result = rv
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// hashRing is a consistent hashing ring, it must be sorted before use.
+type hashRing []hashRingPoint
+
+type hashRingPoint struct {
+	hash   uint64
+	bucket string
+}
+
+func (r *hashRing) add(bucket string, points int64) {
+	for i := int64(0); i < points; i++ {
+		*r = append(*r, hashRingPoint{hash: hash64(bucket, strconv.FormatInt(i, 10)), bucket: bucket})
+	}
+}
+
+func (r hashRing) sort() {
+	sort.Slice(r, func(i, j int) bool {
+		if r[i].hash == r[j].hash {
+			return r[i].bucket < r[j].bucket
+		}
+		return r[i].hash < r[j].hash
+	})
+}
+
+// get returns the bucket of the first point following the key hash.
+func (r hashRing) get(key string) string {
+	h := hash64(key)
+	i := sort.Search(len(r), func(i int) bool { return r[i].hash >= h })
+	if i == len(r) {
+		i = 0
+	}
+	return r[i].bucket
+}
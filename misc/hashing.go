@@ -0,0 +1,38 @@
+package misc
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"sort"
+	"strings"
+)
+
+// hash64 returns the first 8 bytes of the SHA-256 of parts joined by a NUL byte.
+// It's used wherever a stable pseudo-random placement is needed, so it must never change.
+func hash64(parts ...string) uint64 {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return binary.BigEndian.Uint64(sum[:8])
+}
+
+// rankBySeed orders items by the hash of seed and the item. Adding an item only inserts it
+// into the order, the relative order of the other items never changes.
+func rankBySeed(items []string, seed string) []string {
+	ranked := make([]string, len(items))
+	copy(ranked, items)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return hash64(seed, ranked[i]) < hash64(seed, ranked[j])
+	})
+	return ranked
+}
+
+// jumpHash maps the key onto one of the buckets using jump consistent hashing. Adding a bucket
+// moves only the keys which end up in the new bucket.
+func jumpHash(key uint64, buckets int64) int64 {
+	b, j := int64(-1), int64(0)
+	for j < buckets {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+	return b
+}
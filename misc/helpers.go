@@ -1,5 +1,11 @@
 package misc
 
+import (
+	"sort"
+	"strings"
+	"text/template"
+)
+
 func stringInSlice(a string, list []string) bool {
 	for _, b := range list {
 		if b == a {
@@ -18,3 +24,53 @@ func deleteFromSlice(list []string, a string) []string {
 	}
 	return list
 }
+
+// renderTemplate executes text as a Go text/template with vars available as the template data.
+// Missing keys are reported as errors instead of being rendered as "<no value>".
+func renderTemplate(text string, vars map[string]string) (string, error) {
+	t, err := template.New("message").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	if err := t.Execute(&b, vars); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// assignClaims is the allocation logic shared by the resources handing out items to claimers,
+// like claim_from_pool and port_allocator. The previous claims of the claimers are kept when keep
+// reports them still valid, then the other claimers get the item returned by next in sorted order.
+// Claimers for which next returns false are left without a claim.
+func assignClaims[T any](claimers []string, previous map[string]T, keep func(claimer string, item T) bool, next func(claimer string) (T, bool)) map[string]T {
+	sorted := make([]string, len(claimers))
+	copy(sorted, claimers)
+	sort.Strings(sorted)
+
+	claims := map[string]T{}
+	for _, c := range sortedKeys(previous) {
+		if stringInSlice(c, sorted) && keep(c, previous[c]) {
+			claims[c] = previous[c]
+		}
+	}
+	for _, c := range sorted {
+		if _, ok := claims[c]; ok {
+			continue
+		}
+		if item, ok := next(c); ok {
+			claims[c] = item
+		}
+	}
+	return claims
+}
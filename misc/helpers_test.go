@@ -0,0 +1,70 @@
+package misc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAssignClaims(t *testing.T) {
+	cases := []struct {
+		name     string
+		pool     []string
+		claimers []string
+		previous map[string]string
+		want     map[string]string
+	}{
+		{
+			name:     "new claims",
+			pool:     []string{"p1", "p2", "p3"},
+			claimers: []string{"b", "a"},
+			previous: map[string]string{},
+			want:     map[string]string{"a": "p1", "b": "p2"},
+		},
+		{
+			name:     "previous claims are kept",
+			pool:     []string{"p1", "p2", "p3"},
+			claimers: []string{"a", "b", "c"},
+			previous: map[string]string{"b": "p1", "c": "p3"},
+			want:     map[string]string{"a": "p2", "b": "p1", "c": "p3"},
+		},
+		{
+			name:     "claims of removed claimers and items are released",
+			pool:     []string{"p1", "p3", "p4"},
+			claimers: []string{"b", "c", "d"},
+			previous: map[string]string{"a": "p1", "b": "p2", "c": "p3"},
+			want:     map[string]string{"b": "p1", "c": "p3", "d": "p4"},
+		},
+		{
+			name:     "claimers left without items",
+			pool:     []string{"p1"},
+			claimers: []string{"a", "b"},
+			previous: map[string]string{"b": "p1"},
+			want:     map[string]string{"b": "p1"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			free := append([]string{}, c.pool...)
+			got := assignClaims(c.claimers, c.previous,
+				func(_ string, p string) bool {
+					if !stringInSlice(p, free) {
+						return false
+					}
+					free = deleteFromSlice(free, p)
+					return true
+				},
+				func(_ string) (string, bool) {
+					if len(free) == 0 {
+						return "", false
+					}
+					p := free[0]
+					free = free[1:]
+					return p, true
+				})
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("expected %v, got %v", c.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,404 @@
+package misc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	patchFormatJSONPatch  = "json_patch"
+	patchFormatMergePatch = "merge_patch"
+)
+
+var _ datasource.DataSource = &JSONPatchDataSource{}
+
+func NewJSONPatchDataSource() datasource.DataSource {
+	return &JSONPatchDataSource{}
+}
+
+// JSONPatchDataSource defines the data source implementation.
+type JSONPatchDataSource struct{}
+
+// JSONPatchDataSourceModel describes the data source data model.
+type JSONPatchDataSourceModel struct {
+	Id       types.String `tfsdk:"id"`
+	Document types.String `tfsdk:"document"`
+	Patch    types.String `tfsdk:"patch"`
+	Format   types.String `tfsdk:"format"`
+	Result   types.String `tfsdk:"result"`
+}
+
+func (d *JSONPatchDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_json_patch"
+}
+
+func (d *JSONPatchDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Applies an RFC 6902 JSON Patch or an RFC 7386 JSON Merge Patch to a JSON document. " +
+			"Fails naming the operation and the JSON pointer when an operation doesn't apply",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "JSON patch identifier",
+				Computed:            true,
+			},
+			"document": schema.StringAttribute{
+				MarkdownDescription: "JSON document to patch",
+				Required:            true,
+			},
+			"patch": schema.StringAttribute{
+				MarkdownDescription: "JSON patch, an array of operations for `json_patch` or a partial document for `merge_patch`",
+				Required:            true,
+			},
+			"format": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Format of the patch, `%s` or `%s`. Defaults to `%s`",
+					patchFormatJSONPatch, patchFormatMergePatch, patchFormatJSONPatch),
+				Optional: true,
+			},
+			"result": schema.StringAttribute{
+				MarkdownDescription: "Patched JSON document",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *JSONPatchDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JSONPatchDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	format := patchFormatJSONPatch
+	if !data.Format.IsNull() {
+		format = data.Format.ValueString()
+	}
+	if format != patchFormatJSONPatch && format != patchFormatMergePatch {
+		resp.Diagnostics.AddAttributeError(path.Root("format"), "Invalid patch format",
+			fmt.Sprintf("Patch format must be %s or %s, got %q.", patchFormatJSONPatch, patchFormatMergePatch, format))
+	}
+
+	doc, err := decodeJSON(data.Document.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("document"), "Invalid JSON", err.Error())
+	}
+	patch, err := decodeJSON(data.Patch.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("patch"), "Invalid JSON", err.Error())
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if format == patchFormatMergePatch {
+		doc = mergePatch(doc, patch)
+	} else {
+		doc, err = jsonPatch(doc, patch)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("patch"), "JSON patch failed", err.Error()+".")
+			return
+		}
+	}
+
+	r, err := encodeJSON(doc)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to encode the result", err.Error())
+		return
+	}
+
+	data.Id = types.StringValue(time.Now().Format(time.RFC3339Nano))
+	data.Result = types.StringValue(r)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mergePatch applies an RFC 7386 merge patch to target.
+func mergePatch(target, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = map[string]interface{}{}
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+		} else {
+			t[k] = mergePatch(t[k], v)
+		}
+	}
+	return t
+}
+
+// jsonPatch applies an RFC 6902 JSON patch to doc, which may be modified.
+// Errors name the failing operation and the JSON pointer it failed at.
+func jsonPatch(doc, patch interface{}) (interface{}, error) {
+	ops, ok := patch.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("JSON patch must be an array of operations, got %s", jsonTypeName(patch))
+	}
+	for i, o := range ops {
+		op, err := parsePatchOperation(o)
+		if err == nil {
+			doc, err = op.apply(doc)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Operation %d %s: %s", i, op, err)
+		}
+	}
+	return doc, nil
+}
+
+// patchOperation is a single RFC 6902 operation.
+type patchOperation struct {
+	op       string
+	path     string
+	from     string
+	value    interface{}
+	hasValue bool
+}
+
+// String describes the operation for diagnostics, e.g. (replace "/a/0").
+func (o patchOperation) String() string {
+	switch {
+	case o.op == "":
+		return ""
+	case o.op == "move" || o.op == "copy":
+		return fmt.Sprintf("(%s %q to %q)", o.op, o.from, o.path)
+	default:
+		return fmt.Sprintf("(%s %q)", o.op, o.path)
+	}
+}
+
+func parsePatchOperation(v interface{}) (op patchOperation, err error) {
+	o, ok := v.(map[string]interface{})
+	if !ok {
+		return op, fmt.Errorf("operation must be an object, got %s", jsonTypeName(v))
+	}
+
+	member := func(name string) (string, error) {
+		m, ok := o[name]
+		if !ok {
+			return "", fmt.Errorf("missing %q member", name)
+		}
+		s, ok := m.(string)
+		if !ok {
+			return "", fmt.Errorf("%q member must be a string, got %s", name, jsonTypeName(m))
+		}
+		return s, nil
+	}
+
+	name, err := member("op")
+	if err != nil {
+		return op, err
+	}
+	p, err := member("path")
+	if err != nil {
+		return op, err
+	}
+	op = patchOperation{op: name, path: p}
+	op.value, op.hasValue = o["value"]
+
+	switch op.op {
+	case "add", "replace", "test":
+		if !op.hasValue {
+			return op, fmt.Errorf("missing %q member", "value")
+		}
+	case "move", "copy":
+		if op.from, err = member("from"); err != nil {
+			return op, err
+		}
+	case "remove":
+	default:
+		return op, fmt.Errorf("unknown operation %q, must be one of add, remove, replace, move, copy and test", op.op)
+	}
+	return op, nil
+}
+
+// apply returns the document with the operation applied, doc may be modified.
+func (o patchOperation) apply(doc interface{}) (interface{}, error) {
+	tokens, err := parseJSONPointer(o.path)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 && o.op == "remove" {
+		return nil, fmt.Errorf("the whole document can't be removed")
+	}
+
+	switch o.op {
+	case "add":
+		return jsonPatchAt(doc, tokens, nil, jsonAdd(o.value))
+	case "remove":
+		return jsonPatchAt(doc, tokens, nil, jsonRemove)
+	case "replace":
+		return jsonPatchAt(doc, tokens, nil, jsonReplace(o.value))
+	case "test":
+		v, err := jsonGet(doc, tokens)
+		if err != nil {
+			return nil, err
+		}
+		if !jsonEqual(v, o.value) {
+			actual, _ := encodeJSON(v)
+			expected, _ := encodeJSON(o.value)
+			return nil, fmt.Errorf("value at %q is %s, expected %s", o.path, actual, expected)
+		}
+		return doc, nil
+	default:
+		fromTokens, err := parseJSONPointer(o.from)
+		if err != nil {
+			return nil, err
+		}
+		v, err := jsonGet(doc, fromTokens)
+		if err != nil {
+			return nil, err
+		}
+		if o.op == "copy" {
+			return jsonPatchAt(doc, tokens, nil, jsonAdd(copyJSON(v)))
+		}
+		if o.path != o.from && strings.HasPrefix(o.path, o.from+"/") {
+			return nil, fmt.Errorf("%q can't be moved into its own child %q", o.from, o.path)
+		}
+		doc, err = jsonPatchAt(doc, fromTokens, nil, jsonRemove)
+		if err != nil {
+			return nil, err
+		}
+		return jsonPatchAt(doc, tokens, nil, jsonAdd(v))
+	}
+}
+
+// jsonChange changes the member token of parent and returns the new parent, at points to parent.
+type jsonChange func(parent interface{}, token string, at []string) (interface{}, error)
+
+// jsonPatchAt applies change to the parent of the value the tokens point to and returns the new document.
+// The whole document, pointed to by no tokens, is replaced by the value of the change.
+func jsonPatchAt(doc interface{}, tokens, at []string, change jsonChange) (interface{}, error) {
+	if len(tokens) == 0 {
+		parent, err := change(map[string]interface{}{"": doc}, "", at)
+		if err != nil {
+			return nil, err
+		}
+		return parent.(map[string]interface{})[""], nil
+	}
+	if len(tokens) == 1 {
+		return change(doc, tokens[0], at)
+	}
+
+	child, err := jsonChild(doc, tokens[0], at)
+	if err != nil {
+		return nil, err
+	}
+	at = append(at, tokens[0])
+	child, err = jsonPatchAt(child, tokens[1:], at, change)
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := doc.(type) {
+	case map[string]interface{}:
+		t[tokens[0]] = child
+	case []interface{}:
+		i, _ := jsonArrayIndex(tokens[0], len(t), false)
+		t[i] = child
+	}
+	return doc, nil
+}
+
+// jsonChild returns the member token of doc, at points to doc.
+func jsonChild(doc interface{}, token string, at []string) (interface{}, error) {
+	switch t := doc.(type) {
+	case map[string]interface{}:
+		v, ok := t[token]
+		if !ok {
+			return nil, fmt.Errorf("object at %q has no member %q", formatJSONPointer(at), token)
+		}
+		return v, nil
+	case []interface{}:
+		i, err := jsonArrayIndex(token, len(t), false)
+		if err != nil {
+			return nil, fmt.Errorf("array at %q: %s", formatJSONPointer(at), err)
+		}
+		return t[i], nil
+	default:
+		return nil, fmt.Errorf("value at %q is %s, not an object or an array", formatJSONPointer(at), jsonTypeName(doc))
+	}
+}
+
+// jsonGet returns the value the tokens point to.
+func jsonGet(doc interface{}, tokens []string) (interface{}, error) {
+	at := []string{}
+	for _, token := range tokens {
+		child, err := jsonChild(doc, token, at)
+		if err != nil {
+			return nil, err
+		}
+		doc = child
+		at = append(at, token)
+	}
+	return doc, nil
+}
+
+func jsonAdd(value interface{}) jsonChange {
+	return func(parent interface{}, token string, at []string) (interface{}, error) {
+		switch t := parent.(type) {
+		case map[string]interface{}:
+			t[token] = value
+			return t, nil
+		case []interface{}:
+			if token == "-" {
+				return append(t, value), nil
+			}
+			i, err := jsonArrayIndex(token, len(t), true)
+			if err != nil {
+				return nil, fmt.Errorf("array at %q: %s", formatJSONPointer(at), err)
+			}
+			t = append(t, nil)
+			copy(t[i+1:], t[i:])
+			t[i] = value
+			return t, nil
+		}
+		return nil, fmt.Errorf("value at %q is %s, not an object or an array", formatJSONPointer(at), jsonTypeName(parent))
+	}
+}
+
+func jsonRemove(parent interface{}, token string, at []string) (interface{}, error) {
+	if _, err := jsonChild(parent, token, at); err != nil {
+		return nil, err
+	}
+	switch t := parent.(type) {
+	case map[string]interface{}:
+		delete(t, token)
+		return t, nil
+	default:
+		l := t.([]interface{})
+		i, _ := jsonArrayIndex(token, len(l), false)
+		return append(l[:i], l[i+1:]...), nil
+	}
+}
+
+func jsonReplace(value interface{}) jsonChange {
+	return func(parent interface{}, token string, at []string) (interface{}, error) {
+		if _, err := jsonChild(parent, token, at); err != nil {
+			return nil, err
+		}
+		switch t := parent.(type) {
+		case map[string]interface{}:
+			t[token] = value
+			return t, nil
+		default:
+			l := t.([]interface{})
+			i, _ := jsonArrayIndex(token, len(l), false)
+			l[i] = value
+			return l, nil
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package misc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestJSONPatch(t *testing.T) {
+	cases := []struct {
+		name  string
+		doc   string
+		patch string
+		want  string
+		err   string
+	}{
+		// RFC 6902 appendix A
+		{
+			name:  "A.1 adding an object member",
+			doc:   `{"foo":"bar"}`,
+			patch: `[{"op":"add","path":"/baz","value":"qux"}]`,
+			want:  `{"baz":"qux","foo":"bar"}`,
+		},
+		{
+			name:  "A.2 adding an array element",
+			doc:   `{"foo":["bar","baz"]}`,
+			patch: `[{"op":"add","path":"/foo/1","value":"qux"}]`,
+			want:  `{"foo":["bar","qux","baz"]}`,
+		},
+		{
+			name:  "A.3 removing an object member",
+			doc:   `{"baz":"qux","foo":"bar"}`,
+			patch: `[{"op":"remove","path":"/baz"}]`,
+			want:  `{"foo":"bar"}`,
+		},
+		{
+			name:  "A.4 removing an array element",
+			doc:   `{"foo":["bar","qux","baz"]}`,
+			patch: `[{"op":"remove","path":"/foo/1"}]`,
+			want:  `{"foo":["bar","baz"]}`,
+		},
+		{
+			name:  "A.5 replacing a value",
+			doc:   `{"baz":"qux","foo":"bar"}`,
+			patch: `[{"op":"replace","path":"/baz","value":"boo"}]`,
+			want:  `{"baz":"boo","foo":"bar"}`,
+		},
+		{
+			name:  "A.6 moving a value",
+			doc:   `{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`,
+			patch: `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`,
+			want:  `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`,
+		},
+		{
+			name:  "A.7 moving an array element",
+			doc:   `{"foo":["all","grass","cows","eat"]}`,
+			patch: `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`,
+			want:  `{"foo":["all","cows","eat","grass"]}`,
+		},
+		{
+			name:  "A.8 testing a value: success",
+			doc:   `{"baz":"qux","foo":["a",2,"c"]}`,
+			patch: `[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2}]`,
+			want:  `{"baz":"qux","foo":["a",2,"c"]}`,
+		},
+		{
+			name:  "A.9 testing a value: error",
+			doc:   `{"baz":"qux"}`,
+			patch: `[{"op":"test","path":"/baz","value":"bar"}]`,
+			err:   `Operation 0 (test "/baz"): value at "/baz" is "qux", expected "bar"`,
+		},
+		{
+			name:  "A.10 adding a nested member object",
+			doc:   `{"foo":"bar"}`,
+			patch: `[{"op":"add","path":"/child","value":{"grandchild":{}}}]`,
+			want:  `{"foo":"bar","child":{"grandchild":{}}}`,
+		},
+		{
+			name:  "A.11 ignoring unrecognized elements",
+			doc:   `{"foo":"bar"}`,
+			patch: `[{"op":"add","path":"/baz","value":"qux","xyz":123}]`,
+			want:  `{"foo":"bar","baz":"qux"}`,
+		},
+		{
+			name:  "A.12 adding to a nonexistent target",
+			doc:   `{"foo":"bar"}`,
+			patch: `[{"op":"add","path":"/baz/bat","value":"qux"}]`,
+			err:   `Operation 0 (add "/baz/bat"): object at "" has no member "baz"`,
+		},
+		{
+			name:  "A.14 ~ escape ordering",
+			doc:   `{"/":9,"~1":10}`,
+			patch: `[{"op":"test","path":"/~01","value":10}]`,
+			want:  `{"/":9,"~1":10}`,
+		},
+		{
+			name:  "A.15 comparing strings and numbers",
+			doc:   `{"/":9,"~1":10}`,
+			patch: `[{"op":"test","path":"/~01","value":"10"}]`,
+			err:   `Operation 0 (test "/~01"): value at "/~01" is 10, expected "10"`,
+		},
+		{
+			name:  "A.16 adding an array value",
+			doc:   `{"foo":["bar"]}`,
+			patch: `[{"op":"add","path":"/foo/-","value":["abc","def"]}]`,
+			want:  `{"foo":["bar",["abc","def"]]}`,
+		},
+
+		{
+			name:  "copied values are not aliased",
+			doc:   `{"a":{"b":1}}`,
+			patch: `[{"op":"copy","from":"/a","path":"/c"},{"op":"replace","path":"/c/b","value":2}]`,
+			want:  `{"a":{"b":1},"c":{"b":2}}`,
+		},
+		{
+			name:  "moving to the same location",
+			doc:   `{"a":[1,2]}`,
+			patch: `[{"op":"move","from":"/a/0","path":"/a/0"}]`,
+			want:  `{"a":[1,2]}`,
+		},
+		{
+			name:  "moving into a child",
+			doc:   `{"a":{"b":{}}}`,
+			patch: `[{"op":"move","from":"/a","path":"/a/b/c"}]`,
+			err:   `Operation 0 (move "/a" to "/a/b/c"): "/a" can't be moved into its own child "/a/b/c"`,
+		},
+		{
+			name:  "replacing the whole document",
+			doc:   `{"a":1}`,
+			patch: `[{"op":"replace","path":"","value":[1]},{"op":"add","path":"/0","value":0}]`,
+			want:  `[0,1]`,
+		},
+		{
+			name:  "removing the whole document",
+			doc:   `{"a":1}`,
+			patch: `[{"op":"remove","path":""}]`,
+			err:   `Operation 0 (remove ""): the whole document can't be removed`,
+		},
+		{
+			name:  "index out of range",
+			doc:   `{"a":{"b":[1,2,3]}}`,
+			patch: `[{"op":"test","path":"/a","value":{"b":[1,2,3]}},{"op":"remove","path":"/a/b/3"}]`,
+			err:   `Operation 1 (remove "/a/b/3"): array at "/a/b": index 3 is out of range of the array of length 3`,
+		},
+		{
+			name:  "index with a leading zero",
+			doc:   `{"a":[1,2]}`,
+			patch: `[{"op":"add","path":"/a/01","value":0}]`,
+			err:   `Operation 0 (add "/a/01"): array at "/a": "01" is not an array index`,
+		},
+		{
+			name:  "traversing a scalar",
+			doc:   `{"a":"x"}`,
+			patch: `[{"op":"add","path":"/a/b/c","value":0}]`,
+			err:   `Operation 0 (add "/a/b/c"): value at "/a" is string, not an object or an array`,
+		},
+		{
+			name:  "unknown operation",
+			doc:   `{}`,
+			patch: `[{"op":"nope","path":""}]`,
+			err:   `unknown operation "nope"`,
+		},
+		{
+			name:  "missing value",
+			doc:   `{}`,
+			patch: `[{"op":"add","path":"/a"}]`,
+			err:   `missing "value" member`,
+		},
+		{
+			name:  "patch which is not an array",
+			doc:   `{}`,
+			patch: `{"op":"add"}`,
+			err:   `JSON patch must be an array of operations, got object`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			doc, err := decodeJSON(c.doc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			patch, err := decodeJSON(c.patch)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := jsonPatch(doc, patch)
+			if c.err != "" {
+				if err == nil || !strings.Contains(err.Error(), c.err) {
+					t.Fatalf("expected error containing %q, got %v", c.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			want, err := decodeJSON(c.want)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !jsonEqual(got, want) {
+				result, _ := encodeJSON(got)
+				t.Errorf("expected %s, got %s", c.want, result)
+			}
+		})
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	// RFC 7386 appendix A
+	cases := []struct {
+		target string
+		patch  string
+		want   string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+
+	for _, c := range cases {
+		target, err := decodeJSON(c.target)
+		if err != nil {
+			t.Fatal(err)
+		}
+		patch, err := decodeJSON(c.patch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := decodeJSON(c.want)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := mergePatch(target, patch); !jsonEqual(got, want) {
+			result, _ := encodeJSON(got)
+			t.Errorf("merging %s into %s: expected %s, got %s", c.patch, c.target, c.want, result)
+		}
+	}
+}
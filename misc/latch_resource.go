@@ -0,0 +1,174 @@
+package misc
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &latch{}
+	_ resource.ResourceWithImportState = &latch{}
+	_ resource.ResourceWithModifyPlan  = &latch{}
+)
+
+// NewLatchResource is a helper function to simplify the provider implementation.
+func NewLatchResource() resource.Resource {
+	return &latch{}
+}
+
+// latch is the resource implementation.
+type latch struct{}
+
+// latchModel maps the resource schema data.
+type latchModel struct {
+	ID             basetypes.StringValue `tfsdk:"id"`
+	Value          basetypes.StringValue `tfsdk:"value"`
+	ReleaseTrigger basetypes.StringValue `tfsdk:"release_trigger"`
+	Output         basetypes.StringValue `tfsdk:"output"`
+}
+
+// Metadata returns the resource type name.
+func (r *latch) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_latch"
+}
+
+// Schema defines the schema for the resource.
+func (r *latch) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Latch holds the first known value in the output, even if the value changes later. " +
+			"The output is updated to the current value only when the release trigger changes.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Random id.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"value": schema.StringAttribute{
+				Description: "Value to latch.",
+				Optional:    true,
+			},
+			"release_trigger": schema.StringAttribute{
+				Description: "Arbitrary string, the output is updated to the current value whenever it changes.",
+				Optional:    true,
+			},
+			"output": schema.StringAttribute{
+				Description: "Latched value.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *latch) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan, the output is already computed by ModifyPlan
+	var plan latchModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.ID = types.StringValue(time.Now().Format(time.RFC3339Nano))
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *latch) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	return
+}
+
+func (r *latch) update(ctx context.Context, tfplan tfsdk.Plan, tfstate tfsdk.State, diag *diag.Diagnostics) (plan latchModel) {
+	diags := tfplan.Get(ctx, &plan)
+	diag.Append(diags...)
+	if diag.HasError() {
+		return
+	}
+
+	plan.Output = plan.Value
+	if tfstate.Raw.IsNull() {
+		return
+	}
+
+	var state latchModel
+	diags = tfstate.Get(ctx, &state)
+	diag.Append(diags...)
+	if diag.HasError() {
+		return
+	}
+
+	// an unknown trigger may still turn out unchanged, the final plan during apply decides
+	if plan.ReleaseTrigger.IsUnknown() {
+		plan.Output = types.StringUnknown()
+		return
+	}
+
+	// keep the latched value unless released or nothing was latched yet,
+	// otherwise the output is the value, which is planned as unknown if it's not known yet
+	if plan.ReleaseTrigger.Equal(state.ReleaseTrigger) && !state.Output.IsNull() {
+		plan.Output = state.Output
+	}
+
+	return
+}
+
+// ModifyPlan modifies plan in a way to show all the changes before the apply
+func (r *latch) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+
+	// don't modify on delete
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	plan := r.update(ctx, req.Plan, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *latch) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	plan := r.update(ctx, req.Plan, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *latch) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	return
+}
+
+func (r *latch) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
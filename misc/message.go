@@ -0,0 +1,130 @@
+package misc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// renderMessage renders summary and details templates using vars. Without vars the messages are returned as is,
+// so messages written before templating was supported, which may contain "{{", keep working.
+// Template errors are reported against the attribute which failed to render, relative to p.
+func renderMessage(ctx context.Context, p path.Path, summary, details types.String, vars types.Map, diags *diag.Diagnostics) (renderedSummary, renderedDetails string) {
+	if vars.IsNull() || vars.IsUnknown() {
+		return summary.ValueString(), details.ValueString()
+	}
+
+	v := map[string]string{}
+	diags.Append(vars.ElementsAs(ctx, &v, false)...)
+	if diags.HasError() {
+		return
+	}
+
+	var err error
+	renderedSummary, err = renderTemplate(summary.ValueString(), v)
+	if err != nil {
+		diags.AddAttributeError(p.AtName("summary"), "Unable to render summary template", err.Error())
+		return
+	}
+
+	if details.IsNull() {
+		return
+	}
+
+	renderedDetails, err = renderTemplate(details.ValueString(), v)
+	if err != nil {
+		diags.AddAttributeError(p.AtName("details"), "Unable to render details template", err.Error())
+		return
+	}
+
+	return
+}
+
+// joinDetails joins diagnostic details paragraphs, skipping the empty ones.
+func joinDetails(paragraphs ...string) string {
+	nonEmpty := []string{}
+	for _, p := range paragraphs {
+		if p != "" {
+			nonEmpty = append(nonEmpty, p)
+		}
+	}
+	return strings.Join(nonEmpty, "\n\n")
+}
+
+// resolveSubject resolves the subject of a failed check to the path its diagnostics are attached to.
+// Subjects outside of the data source itself (e.g. `var.cidrs[3]`) are attached to the subject attribute
+// and mentioned in the returned note instead.
+func resolveSubject(subject types.String, isAttribute func(name string) bool, diags *diag.Diagnostics) (p path.Path, note string) {
+	if subject.IsNull() {
+		return path.Empty(), ""
+	}
+
+	p, err := parseSubject(subject.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("subject"), "Invalid subject", err.Error())
+		return
+	}
+
+	root := p.Steps()[0].(path.PathStepAttributeName)
+	if !isAttribute(string(root)) {
+		return path.Root("subject"), "Subject: " + subject.ValueString()
+	}
+	return p, ""
+}
+
+// validateSubject ensures subject, when known, is a valid attribute path expression,
+// so that a typo is reported before the check it belongs to fails.
+func validateSubject(p path.Path, subject types.String, diags *diag.Diagnostics) {
+	if subject.IsNull() || subject.IsUnknown() {
+		return
+	}
+
+	if _, err := parseSubject(subject.ValueString()); err != nil {
+		diags.AddAttributeError(p, "Invalid subject", err.Error())
+	}
+}
+
+const (
+	severityError   = "error"
+	severityWarning = "warning"
+	severityInfo    = "info"
+)
+
+// validateSeverity ensures severity, when known, is one of the supported ones.
+func validateSeverity(p path.Path, severity types.String, diags *diag.Diagnostics) {
+	if severity.IsNull() || severity.IsUnknown() {
+		return
+	}
+
+	switch severity.ValueString() {
+	case severityError, severityWarning, severityInfo:
+	default:
+		diags.AddAttributeError(p, "Invalid severity",
+			fmt.Sprintf("Severity must be one of %q, %q or %q, got %q.",
+				severityError, severityWarning, severityInfo, severity.ValueString()))
+	}
+}
+
+// addCheckDiagnostic adds a failed check diagnostic of the given severity, attached to subject unless it's empty.
+// Info severity doesn't produce a diagnostic, it's only logged.
+func addCheckDiagnostic(ctx context.Context, diags *diag.Diagnostics, subject path.Path, severity, summary, details string) {
+	attached := !subject.Equal(path.Empty())
+
+	switch {
+	case severity == severityInfo:
+		tflog.Info(ctx, summary, map[string]interface{}{"details": details, "subject": subject.String()})
+	case severity == severityWarning && attached:
+		diags.AddAttributeWarning(subject, summary, details)
+	case severity == severityWarning:
+		diags.AddWarning(summary, details)
+	case attached:
+		diags.AddAttributeError(subject, summary, details)
+	default:
+		diags.AddError(summary, details)
+	}
+}
@@ -0,0 +1,80 @@
+package misc
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	onUnknownDefer = "defer"
+	onUnknownWarn  = "warn"
+	onUnknownError = "error"
+)
+
+var onUnknownAttribute = schema.StringAttribute{
+	Description: "What to do when the condition is unknown during plan: " +
+		"defer (default) silently evaluates it during apply once it is known, " +
+		"warn does the same but warns about it during plan and error fails the plan.",
+	Optional: true,
+}
+
+// handleUnknown validates on_unknown and reports an unknown condition according to it.
+// It must only be called with plan values, during validation all the input variables are unknown.
+func handleUnknown(onUnknown types.String, unknown bool, diags *diag.Diagnostics) {
+	if onUnknown.IsUnknown() {
+		return
+	}
+
+	switch onUnknown.ValueString() {
+	case "", onUnknownDefer:
+	case onUnknownWarn:
+		if unknown {
+			diags.AddAttributeWarning(path.Root("condition"), "Check deferred to apply",
+				"The condition is not known during plan, it will be evaluated during apply.")
+		}
+	case onUnknownError:
+		if unknown {
+			diags.AddAttributeError(path.Root("condition"), "Condition is unknown",
+				"The condition must be known during plan.")
+		}
+	default:
+		diags.AddAttributeError(path.Root("on_unknown"), "Invalid on_unknown",
+			fmt.Sprintf("on_unknown must be one of %q, %q or %q, got %q.",
+				onUnknownDefer, onUnknownWarn, onUnknownError, onUnknown.ValueString()))
+	}
+}
+
+// containsUnknown reports whether v is unknown or is a collection or an object containing an unknown value.
+func containsUnknown(v attr.Value) bool {
+	if v.IsUnknown() {
+		return true
+	}
+
+	var elements []attr.Value
+	switch v := v.(type) {
+	case types.List:
+		elements = v.Elements()
+	case types.Set:
+		elements = v.Elements()
+	case types.Map:
+		for _, e := range v.Elements() {
+			elements = append(elements, e)
+		}
+	case types.Object:
+		for _, e := range v.Attributes() {
+			elements = append(elements, e)
+		}
+	}
+
+	for _, e := range elements {
+		if containsUnknown(e) {
+			return true
+		}
+	}
+	return false
+}
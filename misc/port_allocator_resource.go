@@ -0,0 +1,398 @@
+package misc
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &portAllocator{}
+	_ resource.ResourceWithModifyPlan     = &portAllocator{}
+	_ resource.ResourceWithValidateConfig = &portAllocator{}
+)
+
+// wellKnownPortsEnd is the last of the well-known ports, which are not allocated unless allowed.
+const wellKnownPortsEnd = 1023
+
+// NewPortAllocatorResource is a helper function to simplify the provider implementation.
+func NewPortAllocatorResource() resource.Resource {
+	return &portAllocator{}
+}
+
+// portAllocator is the resource implementation.
+type portAllocator struct{}
+
+// portAllocatorModel maps the resource schema data.
+type portAllocatorModel struct {
+	ID                  basetypes.StringValue `tfsdk:"id"`
+	RangeStart          basetypes.Int64Value  `tfsdk:"range_start"`
+	RangeEnd            basetypes.Int64Value  `tfsdk:"range_end"`
+	Services            basetypes.MapValue    `tfsdk:"services"`
+	ReservedPorts       basetypes.SetValue    `tfsdk:"reserved_ports"`
+	AllowWellKnown      basetypes.BoolValue   `tfsdk:"allow_well_known"`
+	ExistingAllocations basetypes.MapValue    `tfsdk:"existing_allocations"`
+	Allocations         basetypes.MapValue    `tfsdk:"allocations"`
+}
+
+// portRangeModel maps a single allocation.
+type portRangeModel struct {
+	From basetypes.Int64Value `tfsdk:"from"`
+	To   basetypes.Int64Value `tfsdk:"to"`
+}
+
+var portRangeType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"from": types.Int64Type,
+	"to":   types.Int64Type,
+}}
+
+// Metadata returns the resource type name.
+func (r *portAllocator) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_port_allocator"
+}
+
+// Schema defines the schema for the resource.
+func (r *portAllocator) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Allocates contiguous port ranges per service from a range of ports. " +
+			"Ports are claimed like misc_claim_from_pool claims items, allocations are kept as long as the service and its width don't change.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Random id.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"range_start": schema.Int64Attribute{
+				Description: "First port of the range to allocate from.",
+				Required:    true,
+			},
+			"range_end": schema.Int64Attribute{
+				Description: "Last port of the range to allocate from.",
+				Required:    true,
+			},
+			"services": schema.MapAttribute{
+				ElementType: types.Int64Type,
+				Description: "Map of services and the number of consecutive ports they need (service => width).",
+				Required:    true,
+			},
+			"reserved_ports": schema.SetAttribute{
+				ElementType: types.Int64Type,
+				Description: "Ports which are never allocated.",
+				Optional:    true,
+			},
+			"allow_well_known": schema.BoolAttribute{
+				Description: "Allow allocating well-known ports (0-1023).",
+				Optional:    true,
+			},
+			"existing_allocations": schema.MapAttribute{
+				ElementType: types.StringType,
+				Description: "Ports allocated elsewhere, e.g. {\"legacy\" = \"8000-8004\", \"admin\" = \"9000\"}. " +
+					"Allocations conflicting with them are reported and moved.",
+				Optional: true,
+			},
+			"allocations": schema.MapNestedAttribute{
+				Description: "Map of allocated port ranges (service => range).",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"from": schema.Int64Attribute{
+							Description: "First allocated port.",
+							Computed:    true,
+						},
+						"to": schema.Int64Attribute{
+							Description: "Last allocated port.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *portAllocator) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config portAllocatorModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.RangeStart.IsUnknown() && !config.RangeEnd.IsUnknown() {
+		if config.RangeStart.ValueInt64() < 0 || config.RangeEnd.ValueInt64() > 65535 ||
+			config.RangeStart.ValueInt64() > config.RangeEnd.ValueInt64() {
+			resp.Diagnostics.AddAttributeError(path.Root("range_start"), "Invalid port range",
+				fmt.Sprintf("Port range %d-%d must be within 0-65535 and start before it ends.",
+					config.RangeStart.ValueInt64(), config.RangeEnd.ValueInt64()))
+		}
+	}
+
+	if config.Services.IsUnknown() {
+		return
+	}
+	for service, width := range config.Services.Elements() {
+		w := width.(types.Int64)
+		if !w.IsUnknown() && w.ValueInt64() < 1 {
+			resp.Diagnostics.AddAttributeError(path.Root("services").AtMapKey(service), "Invalid width",
+				fmt.Sprintf("Service %q needs at least one port, got %d.", service, w.ValueInt64()))
+		}
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *portAllocator) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan, the allocations are already computed by ModifyPlan
+	var plan portAllocatorModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.ID = types.StringValue(time.Now().Format(time.RFC3339Nano))
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *portAllocator) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	return
+}
+
+func (r *portAllocator) update(ctx context.Context, tfplan tfsdk.Plan, tfstate tfsdk.State, diag *diag.Diagnostics) (plan portAllocatorModel) {
+	diags := tfplan.Get(ctx, &plan)
+	diag.Append(diags...)
+	if diag.HasError() {
+		return
+	}
+
+	if containsUnknown(plan.RangeStart) || containsUnknown(plan.RangeEnd) || containsUnknown(plan.Services) ||
+		containsUnknown(plan.ReservedPorts) || containsUnknown(plan.AllowWellKnown) || containsUnknown(plan.ExistingAllocations) {
+		plan.Allocations = types.MapUnknown(portRangeType)
+		return
+	}
+
+	stateAllocations := map[string]portRangeModel{}
+	if !tfstate.Raw.IsNull() {
+		var state portAllocatorModel
+		diags = tfstate.Get(ctx, &state)
+		diag.Append(diags...)
+		if diag.HasError() {
+			return
+		}
+
+		if !state.Allocations.IsNull() {
+			diags = state.Allocations.ElementsAs(ctx, &stateAllocations, false)
+			diag.Append(diags...)
+			if diag.HasError() {
+				return
+			}
+		}
+	}
+
+	services := map[string]int64{}
+	reserved := []int64{}
+	existing := map[string]string{}
+	diag.Append(plan.Services.ElementsAs(ctx, &services, false)...)
+	if !plan.ReservedPorts.IsNull() {
+		diag.Append(plan.ReservedPorts.ElementsAs(ctx, &reserved, false)...)
+	}
+	if !plan.ExistingAllocations.IsNull() {
+		diag.Append(plan.ExistingAllocations.ElementsAs(ctx, &existing, false)...)
+	}
+	if diag.HasError() {
+		return
+	}
+
+	ports := newPortSpace(plan.RangeStart.ValueInt64(), plan.RangeEnd.ValueInt64())
+	if !plan.AllowWellKnown.ValueBool() {
+		ports.take(0, wellKnownPortsEnd, "well-known ports")
+	}
+	for _, p := range reserved {
+		ports.take(p, p, "reserved ports")
+	}
+	for _, name := range sortedKeys(existing) {
+		from, to, err := parsePortRange(existing[name])
+		if err != nil {
+			diag.AddAttributeError(path.Root("existing_allocations").AtMapKey(name), "Invalid port range", err.Error())
+			continue
+		}
+		ports.take(from, to, fmt.Sprintf("existing allocation %q", name))
+	}
+	if diag.HasError() {
+		return
+	}
+
+	// keep the previous allocations which are still valid, then allocate the new ones from the free ports
+	allocations := assignClaims(sortedKeys(services), stateAllocations,
+		func(service string, previous portRangeModel) bool {
+			from, to := previous.From.ValueInt64(), previous.To.ValueInt64()
+			if to-from+1 != services[service] {
+				return false
+			}
+			if owner := ports.owner(from, to); owner != "" {
+				diag.AddWarning(fmt.Sprintf("Port allocation of %q conflicts with %s", service, owner),
+					fmt.Sprintf("Ports %d-%d of %q are not available anymore, the service is moved to another range.", from, to, service))
+				return false
+			}
+			ports.take(from, to, fmt.Sprintf("service %q", service))
+			return true
+		},
+		func(service string) (portRangeModel, bool) {
+			width := services[service]
+			from, ok := ports.findFree(width)
+			if !ok {
+				diag.AddAttributeError(path.Root("services").AtMapKey(service), "Not enough free ports",
+					fmt.Sprintf("There are no %d consecutive free ports for %q in range %d-%d.",
+						width, service, plan.RangeStart.ValueInt64(), plan.RangeEnd.ValueInt64()))
+				return portRangeModel{}, false
+			}
+			ports.take(from, from+width-1, fmt.Sprintf("service %q", service))
+			return portRangeModel{From: types.Int64Value(from), To: types.Int64Value(from + width - 1)}, true
+		})
+	if diag.HasError() {
+		return
+	}
+
+	mv, diags := basetypes.NewMapValueFrom(ctx, portRangeType, allocations)
+	diag.Append(diags...)
+	if diag.HasError() {
+		return
+	}
+
+	plan.Allocations = mv
+
+	return
+}
+
+// ModifyPlan modifies plan in a way to show all the changes before the apply
+func (r *portAllocator) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+
+	// don't modify on delete
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	plan := r.update(ctx, req.Plan, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *portAllocator) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	plan := r.update(ctx, req.Plan, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *portAllocator) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	return
+}
+
+// portSpace tracks which ports of a range are taken and by whom.
+type portSpace struct {
+	start, end int64
+	owners     map[int64]string
+}
+
+func newPortSpace(start, end int64) *portSpace {
+	return &portSpace{start: start, end: end, owners: map[int64]string{}}
+}
+
+// take marks ports from-to as taken by owner, ports already taken keep their owner.
+func (s *portSpace) take(from, to int64, owner string) {
+	if from < s.start {
+		from = s.start
+	}
+	if to > s.end {
+		to = s.end
+	}
+	for p := from; p <= to; p++ {
+		if _, ok := s.owners[p]; !ok {
+			s.owners[p] = owner
+		}
+	}
+}
+
+// owner returns the owner of the first taken port from-to, "the port range" when it's out of the range
+// and empty string when all the ports are free.
+func (s *portSpace) owner(from, to int64) string {
+	if from < s.start || to > s.end {
+		return "the port range"
+	}
+	for p := from; p <= to; p++ {
+		if o, ok := s.owners[p]; ok {
+			return o
+		}
+	}
+	return ""
+}
+
+// findFree returns the first port of the lowest block of width free ports.
+func (s *portSpace) findFree(width int64) (int64, bool) {
+	free := int64(0)
+	for p := s.start; p <= s.end; p++ {
+		if _, ok := s.owners[p]; ok {
+			free = 0
+			continue
+		}
+		free++
+		if free == width {
+			return p - width + 1, true
+		}
+	}
+	return 0, false
+}
+
+// parsePortRange parses "from-to" or a single port.
+func parsePortRange(s string) (from, to int64, err error) {
+	fromStr, toStr, isRange := strings.Cut(s, "-")
+	from, err = strconv.ParseInt(strings.TrimSpace(fromStr), 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid port %q in %q", fromStr, s)
+	}
+	if !isRange {
+		return from, from, nil
+	}
+	to, err = strconv.ParseInt(strings.TrimSpace(toStr), 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid port %q in %q", toStr, s)
+	}
+	if from > to {
+		return 0, 0, fmt.Errorf("port range %q starts after it ends", s)
+	}
+	return from, to, nil
+}
@@ -43,7 +43,21 @@ func (p *kiwiProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 // DataSources defines the data sources implemented in the provider.
 func (p *kiwiProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		NewAssertDataSource,
+		NewBatchesDataSource,
+		NewCheckDataSource,
+		NewDeepMergeDataSource,
+		NewDeprecationDataSource,
+		NewDiffDataSource,
 		NewErrorDataSource,
+		NewFeatureFlagDataSource,
+		NewHashRingDataSource,
+		NewJSONPatchDataSource,
+		NewRolloutWavesDataSource,
+		NewSampleDataSource,
+		NewSemverDataSource,
+		NewShuffleDataSource,
+		NewToposortDataSource,
 		NewWarningDataSource,
 	}
 }
@@ -52,6 +66,14 @@ func (p *kiwiProvider) DataSources(_ context.Context) []func() datasource.DataSo
 func (p *kiwiProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewClaimFromPoolResource,
+		NewCounterResource,
+		NewErrorGateResource,
+		NewLatchResource,
+		NewPortAllocatorResource,
+		NewRolloutResource,
+		NewRotationResource,
+		NewSequenceResource,
 		NewStatefulListResource,
+		NewWarningGateResource,
 	}
 }
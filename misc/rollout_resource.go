@@ -0,0 +1,260 @@
+package misc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &rollout{}
+	_ resource.ResourceWithModifyPlan = &rollout{}
+)
+
+// NewRolloutResource is a helper function to simplify the provider implementation.
+func NewRolloutResource() resource.Resource {
+	return &rollout{}
+}
+
+// rollout is the resource implementation.
+type rollout struct{}
+
+// rolloutModel maps the resource schema data.
+type rolloutModel struct {
+	ID             basetypes.StringValue `tfsdk:"id"`
+	Waves          basetypes.ListValue   `tfsdk:"waves"`
+	Advance        basetypes.StringValue `tfsdk:"advance"`
+	HealthChecks   basetypes.ListValue   `tfsdk:"health_checks"`
+	LastAdvance    basetypes.StringValue `tfsdk:"last_advance"`
+	CurrentWave    basetypes.Int64Value  `tfsdk:"current_wave"`
+	EnabledTargets basetypes.SetValue    `tfsdk:"enabled_targets"`
+}
+
+// Metadata returns the resource type name.
+func (r *rollout) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rollout"
+}
+
+// Schema defines the schema for the resource.
+func (r *rollout) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Rollout remembers the active wave and advances by one wave whenever the advance trigger changes " +
+			"and all the health checks pass. Like misc_stateful_list, enabled targets stay enabled.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Random id.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"waves": schema.ListAttribute{
+				ElementType: types.ListType{ElemType: types.StringType},
+				Description: "Ordered waves of targets, e.g. the result of misc_rollout_waves.",
+				Required:    true,
+			},
+			"advance": schema.StringAttribute{
+				Description: "Arbitrary string, the rollout advances to the next wave whenever it changes.",
+				Optional:    true,
+			},
+			"health_checks": schema.ListAttribute{
+				ElementType: types.BoolType,
+				Description: "Conditions which must all be true for the rollout to advance.",
+				Optional:    true,
+			},
+			"last_advance": schema.StringAttribute{
+				Description: "Value of advance the rollout last advanced with. " +
+					"While it differs from advance, e.g. because of failing health checks, the rollout advances on the next apply.",
+				Computed: true,
+			},
+			"current_wave": schema.Int64Attribute{
+				Description: "Index of the active wave.",
+				Computed:    true,
+			},
+			"enabled_targets": schema.SetAttribute{
+				ElementType: types.StringType,
+				Description: "Targets of the active and all the previous waves.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *rollout) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// values unknown during plan are known now, so compute them again without a prior state
+	plan := r.update(ctx, req.Plan, tfsdk.State{}, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.ID = types.StringValue(time.Now().Format(time.RFC3339Nano))
+
+	// Set state to fully populated data
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *rollout) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	return
+}
+
+func (r *rollout) update(ctx context.Context, tfplan tfsdk.Plan, tfstate tfsdk.State, diag *diag.Diagnostics) (plan rolloutModel) {
+	diags := tfplan.Get(ctx, &plan)
+	diag.Append(diags...)
+	if diag.HasError() {
+		return
+	}
+
+	if containsUnknown(plan.Waves) || plan.Advance.IsUnknown() {
+		plan.LastAdvance = types.StringUnknown()
+		plan.CurrentWave = types.Int64Unknown()
+		plan.EnabledTargets = types.SetUnknown(types.StringType)
+		return
+	}
+
+	waves := [][]string{}
+	diag.Append(plan.Waves.ElementsAs(ctx, &waves, false)...)
+	if diag.HasError() {
+		return
+	}
+
+	current := int64(0)
+	lastAdvance := plan.Advance
+	stateEnabled := []string{}
+	if !tfstate.Raw.IsNull() {
+		var state rolloutModel
+		diags = tfstate.Get(ctx, &state)
+		diag.Append(diags...)
+		if diag.HasError() {
+			return
+		}
+
+		diags = state.EnabledTargets.ElementsAs(ctx, &stateEnabled, false)
+		diag.Append(diags...)
+		if diag.HasError() {
+			return
+		}
+
+		current = state.CurrentWave.ValueInt64()
+		lastAdvance = state.LastAdvance
+		if !plan.Advance.Equal(state.LastAdvance) {
+			if containsUnknown(plan.HealthChecks) {
+				plan.LastAdvance = types.StringUnknown()
+				plan.CurrentWave = types.Int64Unknown()
+				plan.EnabledTargets = types.SetUnknown(types.StringType)
+				return
+			}
+
+			healthChecks := []bool{}
+			if !plan.HealthChecks.IsNull() {
+				diag.Append(plan.HealthChecks.ElementsAs(ctx, &healthChecks, false)...)
+				if diag.HasError() {
+					return
+				}
+			}
+
+			failing := 0
+			for _, h := range healthChecks {
+				if !h {
+					failing++
+				}
+			}
+			if failing == 0 {
+				current++
+				lastAdvance = plan.Advance
+			} else {
+				diag.AddWarning("Rollout not advanced",
+					fmt.Sprintf("%d of %d health checks are failing, the rollout stays at wave %d and advances "+
+						"once they pass.", failing, len(healthChecks), current))
+			}
+		}
+	}
+
+	if current > int64(len(waves))-1 {
+		current = int64(len(waves)) - 1
+	}
+	if current < 0 {
+		current = 0
+	}
+
+	// targets stay enabled as long as they are part of the rollout
+	allTargets := []string{}
+	enabled := []string{}
+	for i, wave := range waves {
+		for _, t := range wave {
+			allTargets = append(allTargets, t)
+			if int64(i) <= current && !stringInSlice(t, enabled) {
+				enabled = append(enabled, t)
+			}
+		}
+	}
+	for _, t := range stateEnabled {
+		if stringInSlice(t, allTargets) && !stringInSlice(t, enabled) {
+			enabled = append(enabled, t)
+		}
+	}
+
+	ev, diags := basetypes.NewSetValueFrom(ctx, types.StringType, enabled)
+	diag.Append(diags...)
+	if diag.HasError() {
+		return
+	}
+
+	plan.LastAdvance = lastAdvance
+	plan.CurrentWave = types.Int64Value(current)
+	plan.EnabledTargets = ev
+
+	return
+}
+
+// ModifyPlan modifies plan in a way to show all the changes before the apply
+func (r *rollout) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+
+	// don't modify on delete
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	plan := r.update(ctx, req.Plan, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *rollout) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	plan := r.update(ctx, req.Plan, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *rollout) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	return
+}
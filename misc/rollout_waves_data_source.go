@@ -0,0 +1,181 @@
+package misc
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &RolloutWavesDataSource{}
+
+func NewRolloutWavesDataSource() datasource.DataSource {
+	return &RolloutWavesDataSource{}
+}
+
+// RolloutWavesDataSource defines the data source implementation.
+type RolloutWavesDataSource struct{}
+
+// RolloutWavesDataSourceModel describes the data source data model.
+type RolloutWavesDataSourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	Targets     types.Set    `tfsdk:"targets"`
+	Waves       types.List   `tfsdk:"waves"`
+	Seed        types.String `tfsdk:"seed"`
+	Result      types.List   `tfsdk:"result"`
+	TargetWaves types.Map    `tfsdk:"target_waves"`
+	Unassigned  types.List   `tfsdk:"unassigned"`
+}
+
+// RolloutWaveModel describes a single wave of the rollout.
+type RolloutWaveModel struct {
+	Count      types.Int64   `tfsdk:"count"`
+	Percentage types.Float64 `tfsdk:"percentage"`
+}
+
+func (d *RolloutWavesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rollout_waves"
+}
+
+func (d *RolloutWavesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Splits targets into ordered rollout waves. Membership in percentage waves depends only " +
+			"on the target itself, so targets stay in the same wave when other targets are added",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Rollout waves identifier",
+				Computed:            true,
+			},
+			"targets": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Targets to split into waves",
+				Required:            true,
+			},
+			"waves": schema.ListNestedAttribute{
+				MarkdownDescription: "Ordered waves, each with either `count` or `percentage` set",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"count": schema.Int64Attribute{
+							MarkdownDescription: "Number of targets in the wave, e.g. 1 for a canary",
+							Optional:            true,
+						},
+						"percentage": schema.Float64Attribute{
+							MarkdownDescription: "Percentage of all the targets rolled out once the wave is done, e.g. 10, 50 and 100",
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"seed": schema.StringAttribute{
+				MarkdownDescription: "Seed of the target placement, different seeds give different waves",
+				Optional:            true,
+			},
+			"result": schema.ListAttribute{
+				ElementType:         types.ListType{ElemType: types.StringType},
+				MarkdownDescription: "Targets of each wave, sorted",
+				Computed:            true,
+			},
+			"target_waves": schema.MapAttribute{
+				ElementType:         types.Int64Type,
+				MarkdownDescription: "Map of targets and indexes of their waves (target => wave index)",
+				Computed:            true,
+			},
+			"unassigned": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Targets which didn't fit into any wave, sorted",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *RolloutWavesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RolloutWavesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	targets := []string{}
+	waves := []RolloutWaveModel{}
+	resp.Diagnostics.Append(data.Targets.ElementsAs(ctx, &targets, false)...)
+	resp.Diagnostics.Append(data.Waves.ElementsAs(ctx, &waves, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, w := range waves {
+		p := path.Root("waves").AtListIndex(i)
+		switch {
+		case w.Count.IsNull() == w.Percentage.IsNull():
+			resp.Diagnostics.AddAttributeError(p, "Invalid wave", "Exactly one of count or percentage must be set.")
+		case !w.Count.IsNull() && w.Count.ValueInt64() < 0:
+			resp.Diagnostics.AddAttributeError(p.AtName("count"), "Invalid wave count",
+				fmt.Sprintf("Count must not be negative, got %d.", w.Count.ValueInt64()))
+		case !w.Percentage.IsNull() && (w.Percentage.ValueFloat64() < 0 || w.Percentage.ValueFloat64() > 100):
+			resp.Diagnostics.AddAttributeError(p.AtName("percentage"), "Invalid wave percentage",
+				fmt.Sprintf("Percentage must be between 0 and 100, got %v.", w.Percentage.ValueFloat64()))
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	seed := data.Seed.ValueString()
+	ranked := rankBySeed(targets, seed)
+	targetWaves := map[string]int64{}
+	result := make([][]string, len(waves))
+	for i, w := range waves {
+		result[i] = []string{}
+		taken := int64(0)
+		for _, t := range ranked {
+			if _, ok := targetWaves[t]; ok {
+				continue
+			}
+			if w.Count.IsNull() {
+				if w.Percentage.ValueFloat64() < 100 && float64(hash64(seed, t))/math.MaxUint64*100 >= w.Percentage.ValueFloat64() {
+					continue
+				}
+			} else if taken >= w.Count.ValueInt64() {
+				break
+			}
+			targetWaves[t] = int64(i)
+			result[i] = append(result[i], t)
+			taken++
+		}
+		sort.Strings(result[i])
+	}
+
+	unassigned := []string{}
+	for _, t := range targets {
+		if _, ok := targetWaves[t]; !ok {
+			unassigned = append(unassigned, t)
+		}
+	}
+	sort.Strings(unassigned)
+
+	rv, diags := types.ListValueFrom(ctx, types.ListType{ElemType: types.StringType}, result)
+	resp.Diagnostics.Append(diags...)
+	tv, diags := types.MapValueFrom(ctx, types.Int64Type, targetWaves)
+	resp.Diagnostics.Append(diags...)
+	uv, diags := types.ListValueFrom(ctx, types.StringType, unassigned)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(time.Now().Format(time.RFC3339Nano))
+	data.Result = rv
+	data.TargetWaves = tv
+	data.Unassigned = uv
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,257 @@
+package misc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &rotation{}
+	_ resource.ResourceWithModifyPlan     = &rotation{}
+	_ resource.ResourceWithValidateConfig = &rotation{}
+)
+
+// NewRotationResource is a helper function to simplify the provider implementation.
+func NewRotationResource() resource.Resource {
+	return &rotation{}
+}
+
+// rotation is the resource implementation.
+type rotation struct{}
+
+// rotationModel maps the resource schema data.
+type rotationModel struct {
+	ID             basetypes.StringValue `tfsdk:"id"`
+	RotationPeriod basetypes.StringValue `tfsdk:"rotation_period"`
+	Triggers       basetypes.MapValue    `tfsdk:"triggers"`
+	CreatedAt      basetypes.StringValue `tfsdk:"created_at"`
+	NextRotation   basetypes.StringValue `tfsdk:"next_rotation"`
+	RotationDue    basetypes.BoolValue   `tfsdk:"rotation_due"`
+}
+
+// Metadata returns the resource type name.
+func (r *rotation) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rotation"
+}
+
+// Schema defines the schema for the resource.
+func (r *rotation) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Records its creation time and is replaced once the rotation period passed. " +
+			"Resources depending on it can be rotated by referencing its id or created_at.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Random id.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rotation_period": schema.StringAttribute{
+				Description: "Rotation period as a Go duration, e.g. \"720h\".",
+				Required:    true,
+			},
+			"triggers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Description: "Arbitrary map of values, the resource is replaced whenever it changes.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				Description: "Creation time in RFC 3339 format.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"next_rotation": schema.StringAttribute{
+				Description: "Time of the next rotation in RFC 3339 format.",
+				Computed:    true,
+			},
+			"rotation_due": schema.BoolAttribute{
+				Description: "Whether the rotation time had passed when the state was last refreshed, in which case the resource is replaced.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *rotation) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config rotationModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.RotationPeriod.IsUnknown() {
+		return
+	}
+
+	parseRotationPeriod(config.RotationPeriod, &resp.Diagnostics)
+}
+
+// parseRotationPeriod parses the rotation period, which must be positive.
+func parseRotationPeriod(period basetypes.StringValue, diags *diag.Diagnostics) time.Duration {
+	d, err := time.ParseDuration(period.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("rotation_period"), "Invalid rotation period", err.Error())
+		return 0
+	}
+	if d <= 0 {
+		diags.AddAttributeError(path.Root("rotation_period"), "Invalid rotation period",
+			fmt.Sprintf("Rotation period must be positive, got %s.", period.ValueString()))
+	}
+	return d
+}
+
+// schedule sets the next rotation based on the creation time and the rotation period,
+// and marks the rotation due once now is past it. A due rotation stays due.
+func (r *rotation) schedule(model *rotationModel, now time.Time, diags *diag.Diagnostics) {
+	if model.RotationPeriod.IsUnknown() || model.CreatedAt.IsUnknown() {
+		model.NextRotation = types.StringUnknown()
+		model.RotationDue = types.BoolUnknown()
+		return
+	}
+
+	period := parseRotationPeriod(model.RotationPeriod, diags)
+	createdAt, err := time.Parse(time.RFC3339, model.CreatedAt.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("created_at"), "Invalid creation time", err.Error())
+	}
+	if diags.HasError() {
+		return
+	}
+
+	next := createdAt.Add(period)
+	model.NextRotation = types.StringValue(next.Format(time.RFC3339))
+	model.RotationDue = types.BoolValue(model.RotationDue.ValueBool() || !now.Before(next))
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *rotation) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan rotationModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	now := time.Now().UTC()
+	plan.ID = types.StringValue(now.Format(time.RFC3339Nano))
+	plan.CreatedAt = types.StringValue(now.Format(time.RFC3339))
+	r.schedule(&plan, now, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *rotation) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state rotationModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.schedule(&state, time.Now(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// ModifyPlan modifies plan in a way to show all the changes before the apply
+func (r *rotation) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+
+	// don't modify on create and delete
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state rotationModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the rotation is due only if the refreshed state says so, not by the current time, which would
+	// differ when Terraform plans again during apply, e.g. when a saved plan is applied later.
+	// Scheduling at the zero time keeps the due flag of the state.
+	plan.RotationDue = state.RotationDue
+	r.schedule(&plan, time.Time{}, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the values kept from the state by the attribute plan modifiers are recreated on replacement
+	if state.RotationDue.ValueBool() || !plan.Triggers.Equal(state.Triggers) {
+		plan.ID = types.StringUnknown()
+		plan.CreatedAt = types.StringUnknown()
+		plan.NextRotation = types.StringUnknown()
+		plan.RotationDue = types.BoolUnknown()
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("created_at"))
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *rotation) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan rotationModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the schedule is planned by ModifyPlan unless the rotation period wasn't known during plan
+	if plan.NextRotation.IsUnknown() {
+		r.schedule(&plan, time.Now(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *rotation) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	return
+}
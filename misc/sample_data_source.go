@@ -0,0 +1,102 @@
+package misc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &SampleDataSource{}
+
+func NewSampleDataSource() datasource.DataSource {
+	return &SampleDataSource{}
+}
+
+// SampleDataSource defines the data source implementation.
+type SampleDataSource struct{}
+
+// SampleDataSourceModel describes the data source data model.
+type SampleDataSourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	Input       types.List   `tfsdk:"input"`
+	Seed        types.String `tfsdk:"seed"`
+	ResultCount types.Int64  `tfsdk:"result_count"`
+	Result      types.List   `tfsdk:"result"`
+}
+
+func (d *SampleDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sample"
+}
+
+func (d *SampleDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Deterministic subset of a list. Adding an element replaces at most one element of the result",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Sample identifier",
+				Computed:            true,
+			},
+			"input": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "List to sample from",
+				Required:            true,
+			},
+			"seed": schema.StringAttribute{
+				MarkdownDescription: "Seed of the sample, different seeds give different samples",
+				Optional:            true,
+			},
+			"result_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of elements to sample, the whole input is returned when it's shorter",
+				Required:            true,
+			},
+			"result": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Sampled elements in the order of `misc_shuffle` with the same seed",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *SampleDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SampleDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input := []string{}
+	resp.Diagnostics.Append(data.Input.ElementsAs(ctx, &input, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	count := data.ResultCount.ValueInt64()
+	if count < 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("result_count"), "Invalid result count",
+			fmt.Sprintf("Result count must not be negative, got %d.", count))
+		return
+	}
+
+	sample := rankBySeed(input, data.Seed.ValueString())
+	if int64(len(sample)) > count {
+		sample = sample[:count]
+	}
+
+	result, diags := types.ListValueFrom(ctx, types.StringType, sample)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(time.Now().Format(time.RFC3339Nano))
+	data.Result = result
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,219 @@
+package misc
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &SemverDataSource{}
+
+func NewSemverDataSource() datasource.DataSource {
+	return &SemverDataSource{}
+}
+
+// SemverDataSource defines the data source implementation.
+type SemverDataSource struct{}
+
+// SemverDataSourceModel describes the data source data model.
+type SemverDataSourceModel struct {
+	Id            types.String `tfsdk:"id"`
+	Versions      types.List   `tfsdk:"versions"`
+	Constraint    types.String `tfsdk:"constraint"`
+	IgnoreInvalid types.Bool   `tfsdk:"ignore_invalid"`
+	Sorted        types.List   `tfsdk:"sorted"`
+	Matching      types.List   `tfsdk:"matching"`
+	Latest        types.String `tfsdk:"latest"`
+	Invalid       types.List   `tfsdk:"invalid"`
+	Parsed        types.Map    `tfsdk:"parsed"`
+}
+
+// SemverVersionModel describes a parsed version.
+type SemverVersionModel struct {
+	Major      types.Int64  `tfsdk:"major"`
+	Minor      types.Int64  `tfsdk:"minor"`
+	Patch      types.Int64  `tfsdk:"patch"`
+	Prerelease types.String `tfsdk:"prerelease"`
+	Metadata   types.String `tfsdk:"metadata"`
+}
+
+var semverVersionType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"major":      types.Int64Type,
+	"minor":      types.Int64Type,
+	"patch":      types.Int64Type,
+	"prerelease": types.StringType,
+	"metadata":   types.StringType,
+}}
+
+func (d *SemverDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_semver"
+}
+
+func (d *SemverDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Parses and sorts semantic versions and picks the latest one matching a constraint. " +
+			"Constraints are comma separated conditions like `~> 1.2` or `>= 1.0, < 2.0`, " +
+			"prereleases match only conditions on the same prerelease version",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Semver identifier",
+				Computed:            true,
+			},
+			"versions": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Candidate versions, e.g. `1.2.3`, `v1.2` or `1.3.0-rc.1`",
+				Required:            true,
+			},
+			"constraint": schema.StringAttribute{
+				MarkdownDescription: "Version constraint the matching versions satisfy, all the versions match if not set",
+				Optional:            true,
+			},
+			"ignore_invalid": schema.BoolAttribute{
+				MarkdownDescription: "Skip invalid versions instead of failing",
+				Optional:            true,
+			},
+			"sorted": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Valid versions from the lowest to the highest",
+				Computed:            true,
+			},
+			"matching": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Versions satisfying the constraint from the lowest to the highest",
+				Computed:            true,
+			},
+			"latest": schema.StringAttribute{
+				MarkdownDescription: "Highest version satisfying the constraint, null if there is none",
+				Computed:            true,
+			},
+			"invalid": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Versions which couldn't be parsed",
+				Computed:            true,
+			},
+			"parsed": schema.MapNestedAttribute{
+				MarkdownDescription: "Map of valid versions and their parts (version => parts)",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"major": schema.Int64Attribute{
+							MarkdownDescription: "Major version",
+							Computed:            true,
+						},
+						"minor": schema.Int64Attribute{
+							MarkdownDescription: "Minor version",
+							Computed:            true,
+						},
+						"patch": schema.Int64Attribute{
+							MarkdownDescription: "Patch version",
+							Computed:            true,
+						},
+						"prerelease": schema.StringAttribute{
+							MarkdownDescription: "Prerelease, e.g. `rc.1`, empty for releases",
+							Computed:            true,
+						},
+						"metadata": schema.StringAttribute{
+							MarkdownDescription: "Build metadata, empty if there is none",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *SemverDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SemverDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	candidates := []string{}
+	resp.Diagnostics.Append(data.Versions.ElementsAs(ctx, &candidates, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var constraints version.Constraints
+	if !data.Constraint.IsNull() {
+		var err error
+		constraints, err = version.NewConstraint(data.Constraint.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("constraint"), "Invalid version constraint", err.Error())
+			return
+		}
+	}
+
+	versions := []*version.Version{}
+	invalid := []string{}
+	parsed := map[string]SemverVersionModel{}
+	for i, s := range candidates {
+		v, err := version.NewVersion(s)
+		if err != nil {
+			if !data.IgnoreInvalid.ValueBool() {
+				resp.Diagnostics.AddAttributeError(path.Root("versions").AtListIndex(i), "Invalid version", err.Error())
+			}
+			invalid = append(invalid, s)
+			continue
+		}
+		versions = append(versions, v)
+		segments := v.Segments64()
+		parsed[s] = SemverVersionModel{
+			Major:      types.Int64Value(segments[0]),
+			Minor:      types.Int64Value(segments[1]),
+			Patch:      types.Int64Value(segments[2]),
+			Prerelease: types.StringValue(v.Prerelease()),
+			Metadata:   types.StringValue(v.Metadata()),
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].LessThan(versions[j])
+	})
+	sorted := []string{}
+	matching := []string{}
+	for _, v := range versions {
+		sorted = append(sorted, v.Original())
+		if constraints == nil || constraints.Check(v) {
+			matching = append(matching, v.Original())
+		}
+	}
+
+	data.Latest = types.StringNull()
+	if len(matching) > 0 {
+		data.Latest = types.StringValue(matching[len(matching)-1])
+	}
+
+	sv, diags := types.ListValueFrom(ctx, types.StringType, sorted)
+	resp.Diagnostics.Append(diags...)
+	mv, diags := types.ListValueFrom(ctx, types.StringType, matching)
+	resp.Diagnostics.Append(diags...)
+	iv, diags := types.ListValueFrom(ctx, types.StringType, invalid)
+	resp.Diagnostics.Append(diags...)
+	pv, diags := types.MapValueFrom(ctx, semverVersionType, parsed)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(time.Now().Format(time.RFC3339Nano))
+	data.Sorted = sv
+	data.Matching = mv
+	data.Invalid = iv
+	data.Parsed = pv
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
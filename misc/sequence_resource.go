@@ -0,0 +1,256 @@
+package misc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &sequence{}
+	_ resource.ResourceWithImportState = &sequence{}
+	_ resource.ResourceWithModifyPlan  = &sequence{}
+)
+
+// NewSequenceResource is a helper function to simplify the provider implementation.
+func NewSequenceResource() resource.Resource {
+	return &sequence{}
+}
+
+// sequence is the resource implementation.
+type sequence struct{}
+
+// sequenceModel maps the resource schema data.
+type sequenceModel struct {
+	ID     basetypes.StringValue `tfsdk:"id"`
+	Keys   basetypes.SetValue    `tfsdk:"keys"`
+	Base   basetypes.Int64Value  `tfsdk:"base"`
+	Output basetypes.MapValue    `tfsdk:"output"`
+	Next   basetypes.Int64Value  `tfsdk:"next"`
+}
+
+// Metadata returns the resource type name.
+func (r *sequence) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sequence"
+}
+
+// Schema defines the schema for the resource.
+func (r *sequence) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Assigns each key a unique integer, stable across applies. " +
+			"Integers of removed keys are never reused. " +
+			"Import with a JSON object of the already assigned integers (key => integer) as the ID, e.g. {\"a\":0,\"b\":1}.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Random id.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"keys": schema.SetAttribute{
+				ElementType: types.StringType,
+				Description: "Set of keys to assign integers to.",
+				Required:    true,
+			},
+			"base": schema.Int64Attribute{
+				Description: "First integer of the sequence. Defaults to 0.",
+				Optional:    true,
+			},
+			"output": schema.MapAttribute{
+				ElementType: types.Int64Type,
+				Description: "Map of assigned integers (key => integer).",
+				Computed:    true,
+			},
+			"next": schema.Int64Attribute{
+				Description: "Integer which will be assigned to the next new key.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *sequence) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan, the output is already computed by ModifyPlan
+	var plan sequenceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.ID = types.StringValue(time.Now().Format(time.RFC3339Nano))
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *sequence) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	return
+}
+
+func (r *sequence) update(ctx context.Context, tfplan tfsdk.Plan, tfstate tfsdk.State, diag *diag.Diagnostics) (plan sequenceModel) {
+	diags := tfplan.Get(ctx, &plan)
+	diag.Append(diags...)
+	if diag.HasError() {
+		return
+	}
+
+	if containsUnknown(plan.Keys) || plan.Base.IsUnknown() {
+		plan.Output = types.MapUnknown(types.Int64Type)
+		plan.Next = types.Int64Unknown()
+		return
+	}
+
+	stateOutput := map[string]int64{}
+	next := plan.Base.ValueInt64()
+	if !tfstate.Raw.IsNull() {
+		var state sequenceModel
+		diags = tfstate.Get(ctx, &state)
+		diag.Append(diags...)
+		if diag.HasError() {
+			return
+		}
+
+		diags = state.Output.ElementsAs(ctx, &stateOutput, false)
+		diag.Append(diags...)
+		if diag.HasError() {
+			return
+		}
+
+		if state.Next.ValueInt64() > next {
+			next = state.Next.ValueInt64()
+		}
+	}
+
+	planKeys := []string{}
+	diag.Append(plan.Keys.ElementsAs(ctx, &planKeys, false)...)
+	if diag.HasError() {
+		return
+	}
+	sort.Strings(planKeys)
+
+	for k := range stateOutput {
+		if !stringInSlice(k, planKeys) {
+			delete(stateOutput, k)
+		}
+	}
+
+	for _, k := range planKeys {
+		if _, ok := stateOutput[k]; !ok {
+			stateOutput[k] = next
+			next++
+		}
+	}
+
+	mv, diags := basetypes.NewMapValueFrom(ctx, types.Int64Type, stateOutput)
+	diag.Append(diags...)
+	if diag.HasError() {
+		return
+	}
+
+	plan.Output = mv
+	plan.Next = types.Int64Value(next)
+
+	return
+}
+
+// ModifyPlan modifies plan in a way to show all the changes before the apply
+func (r *sequence) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+
+	// don't modify on delete
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	plan := r.update(ctx, req.Plan, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *sequence) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	plan := r.update(ctx, req.Plan, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *sequence) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	return
+}
+
+// ImportState imports the assigned integers from a JSON object (key => integer) given as the import ID,
+// the next new key is assigned the integer after the highest one so that none of them is reused.
+func (r *sequence) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	output := map[string]int64{}
+	if err := json.Unmarshal([]byte(req.ID), &output); err != nil || len(output) == 0 {
+		resp.Diagnostics.AddError("Invalid import ID",
+			fmt.Sprintf("The import ID must be a non-empty JSON object of the assigned integers (key => integer), "+
+				"e.g. {\"a\":0,\"b\":1}, got %q.", req.ID))
+		return
+	}
+
+	keys := []string{}
+	assigned := map[int64]string{}
+	var next int64
+	for k, v := range output {
+		if other, ok := assigned[v]; ok {
+			resp.Diagnostics.AddError("Invalid import ID",
+				fmt.Sprintf("Keys %q and %q are assigned the same integer %d.", other, k, v))
+			return
+		}
+		assigned[v] = k
+		keys = append(keys, k)
+		if len(keys) == 1 || v >= next {
+			next = v + 1
+		}
+	}
+
+	kv, diags := types.SetValueFrom(ctx, types.StringType, keys)
+	resp.Diagnostics.Append(diags...)
+	ov, diags := types.MapValueFrom(ctx, types.Int64Type, output)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := sequenceModel{
+		ID:     types.StringValue(time.Now().Format(time.RFC3339Nano)),
+		Keys:   kv,
+		Base:   types.Int64Null(),
+		Output: ov,
+		Next:   types.Int64Value(next),
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
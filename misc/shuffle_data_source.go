@@ -0,0 +1,84 @@
+package misc
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &ShuffleDataSource{}
+
+func NewShuffleDataSource() datasource.DataSource {
+	return &ShuffleDataSource{}
+}
+
+// ShuffleDataSource defines the data source implementation.
+type ShuffleDataSource struct{}
+
+// ShuffleDataSourceModel describes the data source data model.
+type ShuffleDataSourceModel struct {
+	Id     types.String `tfsdk:"id"`
+	Input  types.List   `tfsdk:"input"`
+	Seed   types.String `tfsdk:"seed"`
+	Result types.List   `tfsdk:"result"`
+}
+
+func (d *ShuffleDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_shuffle"
+}
+
+func (d *ShuffleDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Deterministic permutation of a list. Adding an element only inserts it into the result, " +
+			"the relative order of the other elements doesn't change",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Shuffle identifier",
+				Computed:            true,
+			},
+			"input": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "List to shuffle",
+				Required:            true,
+			},
+			"seed": schema.StringAttribute{
+				MarkdownDescription: "Seed of the permutation, different seeds give different permutations",
+				Optional:            true,
+			},
+			"result": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Shuffled list",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ShuffleDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ShuffleDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input := []string{}
+	resp.Diagnostics.Append(data.Input.ElementsAs(ctx, &input, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, diags := types.ListValueFrom(ctx, types.StringType, rankBySeed(input, data.Seed.ValueString()))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(time.Now().Format(time.RFC3339Nano))
+	data.Result = result
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,58 @@
+package misc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// parseSubject parses an attribute path expression like `a.b[0]["key"]` into a path.
+func parseSubject(subject string) (path.Path, error) {
+	s := subject
+	name, s := splitName(s)
+	if name == "" {
+		return path.Empty(), fmt.Errorf("%q doesn't start with an attribute name", subject)
+	}
+	p := path.Root(name)
+
+	for s != "" {
+		switch s[0] {
+		case '.':
+			name, s = splitName(s[1:])
+			if name == "" {
+				return path.Empty(), fmt.Errorf("missing attribute name after \".\" in %q", subject)
+			}
+			p = p.AtName(name)
+		case '[':
+			end := strings.IndexByte(s, ']')
+			if end < 0 {
+				return path.Empty(), fmt.Errorf("unterminated \"[\" in %q", subject)
+			}
+			step := s[1:end]
+			s = s[end+1:]
+			if key, err := strconv.Unquote(step); err == nil {
+				p = p.AtMapKey(key)
+			} else if index, err := strconv.Atoi(step); err == nil && index >= 0 {
+				p = p.AtListIndex(index)
+			} else {
+				return path.Empty(), fmt.Errorf("invalid index %q in %q, expected a number or a quoted key", step, subject)
+			}
+		default:
+			return path.Empty(), fmt.Errorf("unexpected %q in %q", s[0], subject)
+		}
+	}
+
+	return p, nil
+}
+
+// splitName splits the leading attribute name from s.
+func splitName(s string) (name, rest string) {
+	i := 0
+	for i < len(s) && (s[i] == '_' || s[i] == '-' ||
+		'a' <= s[i] && s[i] <= 'z' || 'A' <= s[i] && s[i] <= 'Z' || '0' <= s[i] && s[i] <= '9') {
+		i++
+	}
+	return s[:i], s[i:]
+}
@@ -0,0 +1,162 @@
+package misc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &ToposortDataSource{}
+
+func NewToposortDataSource() datasource.DataSource {
+	return &ToposortDataSource{}
+}
+
+// ToposortDataSource defines the data source implementation.
+type ToposortDataSource struct{}
+
+// ToposortDataSourceModel describes the data source data model.
+type ToposortDataSourceModel struct {
+	Id           types.String `tfsdk:"id"`
+	Dependencies types.Map    `tfsdk:"dependencies"`
+	Order        types.List   `tfsdk:"order"`
+	Levels       types.List   `tfsdk:"levels"`
+}
+
+func (d *ToposortDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_toposort"
+}
+
+func (d *ToposortDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Orders nodes so that every node comes after its dependencies, fails naming the cycle if there is one",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Toposort identifier",
+				Computed:            true,
+			},
+			"dependencies": schema.MapAttribute{
+				ElementType:         types.ListType{ElemType: types.StringType},
+				MarkdownDescription: "Map of nodes and their dependencies (node => dependencies), dependencies don't have to be listed as nodes",
+				Required:            true,
+			},
+			"order": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "All the nodes, dependencies first",
+				Computed:            true,
+			},
+			"levels": schema.ListAttribute{
+				ElementType: types.ListType{ElemType: types.StringType},
+				MarkdownDescription: "Nodes grouped by level, sorted. The first level has no dependencies, " +
+					"the others depend only on the previous levels, so the nodes of a level can be processed in parallel",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *ToposortDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ToposortDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dependencies := map[string][]string{}
+	resp.Diagnostics.Append(data.Dependencies.ElementsAs(ctx, &dependencies, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for _, deps := range dependencies {
+		for _, dep := range deps {
+			if _, ok := dependencies[dep]; !ok {
+				dependencies[dep] = []string{}
+			}
+		}
+	}
+
+	// Kahn's algorithm, taking all the nodes with satisfied dependencies at once
+	done := map[string]bool{}
+	levels := [][]string{}
+	order := []string{}
+	for len(done) < len(dependencies) {
+		level := []string{}
+		for _, node := range sortedKeys(dependencies) {
+			if done[node] {
+				continue
+			}
+			ready := true
+			for _, dep := range dependencies[node] {
+				if !done[dep] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				level = append(level, node)
+			}
+		}
+		if len(level) == 0 {
+			cycle := findCycle(dependencies, done)
+			resp.Diagnostics.AddAttributeError(path.Root("dependencies").AtMapKey(cycle[0]), "Dependency cycle",
+				fmt.Sprintf("Nodes depend on each other: %s.", strings.Join(cycle, " -> ")))
+			return
+		}
+		for _, node := range level {
+			done[node] = true
+		}
+		levels = append(levels, level)
+		order = append(order, level...)
+	}
+
+	ov, diags := types.ListValueFrom(ctx, types.StringType, order)
+	resp.Diagnostics.Append(diags...)
+	lv, diags := types.ListValueFrom(ctx, types.ListType{ElemType: types.StringType}, levels)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(time.Now().Format(time.RFC3339Nano))
+	data.Order = ov
+	data.Levels = lv
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// findCycle returns a cycle among the nodes which aren't done, starting and ending with the same node.
+// Every such node has a dependency which isn't done, so following them must eventually revisit a node.
+func findCycle(dependencies map[string][]string, done map[string]bool) []string {
+	visited := map[string]int{}
+	walk := []string{}
+	node := ""
+	for _, n := range sortedKeys(dependencies) {
+		if !done[n] {
+			node = n
+			break
+		}
+	}
+	for {
+		if i, ok := visited[node]; ok {
+			return append(walk[i:], node)
+		}
+		visited[node] = len(walk)
+		walk = append(walk, node)
+		deps := append([]string{}, dependencies[node]...)
+		sort.Strings(deps)
+		for _, dep := range deps {
+			if !done[dep] {
+				node = dep
+				break
+			}
+		}
+	}
+}